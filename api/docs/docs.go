@@ -31,6 +31,65 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/changes": {
+            "get": {
+                "description": "Returns schedule rows and events modified after since cursor, next value of response is cursor for the following request, hasMore is set when the following request returns more changes immediately",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Changes"
+                ],
+                "summary": "Schedule rows and events changed after cursor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "next cursor of previous response or moment in RFC 3339 format for the first request e.g. 2021-10-10T12:00:00Z",
+                        "name": "since",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 5000,
+                        "type": "integer",
+                        "default": 500,
+                        "description": "max count of returned schedule rows and events",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "en",
+                        "description": "language code value",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.Changes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.BadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
         "/countries": {
             "get": {
                 "description": "Returns list of countries translated to specified language.",
@@ -106,6 +165,12 @@ var doc = `{
                         "description": "language code value",
                         "name": "lang",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "snapshot moment in RFC 3339 format e.g. 2021-10-10T12:00:00Z",
+                        "name": "asOf",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -192,7 +257,7 @@ var doc = `{
         },
         "/events/{eventId}/history": {
             "get": {
-                "description": "Returns event history list by event id",
+                "description": "Returns event history list by event id with first-print and revised values of every release",
                 "consumes": [
                     "application/json"
                 ],
@@ -210,6 +275,79 @@ var doc = `{
                         "name": "eventId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "snapshot moment in RFC 3339 format e.g. 2021-10-10T12:00:00Z",
+                        "name": "asOf",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/data.EventHistoryRow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.BadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/holidays": {
+            "get": {
+                "description": "Returns holidays and market closures list in dates diapasone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Holidays"
+                ],
+                "summary": "Holidays and bank closures between dates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "from date string in ISO 8601 format e.g. 2021-10-10",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "to date string in ISO 8601 format e.g. 2021-10-10",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "country code value e.g. KR",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "en",
+                        "description": "language code value",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -218,7 +356,7 @@ var doc = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/data.EventRow"
+                                "$ref": "#/definitions/data.Holiday"
                             }
                         }
                     },
@@ -239,6 +377,32 @@ var doc = `{
         }
     },
     "definitions": {
+        "data.Changes": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.EventChange"
+                    }
+                },
+                "hasMore": {
+                    "type": "boolean"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.ScheduleChange"
+                    }
+                },
+                "since": {
+                    "type": "string"
+                }
+            }
+        },
         "data.Country": {
             "type": "object",
             "properties": {
@@ -270,6 +434,18 @@ var doc = `{
                 "actual": {
                     "type": "number"
                 },
+                "actualRaw": {
+                    "type": "string"
+                },
+                "actualScale": {
+                    "type": "string"
+                },
+                "actualUnit": {
+                    "type": "string"
+                },
+                "actualValue": {
+                    "type": "number"
+                },
                 "code": {
                     "type": "string"
                 },
@@ -279,6 +455,18 @@ var doc = `{
                 "forecast": {
                     "type": "number"
                 },
+                "forecastRaw": {
+                    "type": "string"
+                },
+                "forecastScale": {
+                    "type": "string"
+                },
+                "forecastUnit": {
+                    "type": "string"
+                },
+                "forecastValue": {
+                    "type": "number"
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -288,6 +476,18 @@ var doc = `{
                 "previous": {
                     "type": "number"
                 },
+                "previousRaw": {
+                    "type": "string"
+                },
+                "previousScale": {
+                    "type": "string"
+                },
+                "previousUnit": {
+                    "type": "string"
+                },
+                "previousValue": {
+                    "type": "number"
+                },
                 "timestamp": {
                     "type": "string"
                 },
@@ -302,12 +502,56 @@ var doc = `{
                 }
             }
         },
+        "data.EventChange": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "impactLevel": {
+                    "type": "integer"
+                },
+                "overview": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "sourceUrl": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
         "data.EventDetails": {
             "type": "object",
             "properties": {
                 "actual": {
                     "type": "number"
                 },
+                "actualRaw": {
+                    "type": "string"
+                },
+                "actualScale": {
+                    "type": "string"
+                },
+                "actualUnit": {
+                    "type": "string"
+                },
+                "actualValue": {
+                    "type": "number"
+                },
                 "code": {
                     "type": "string"
                 },
@@ -317,6 +561,18 @@ var doc = `{
                 "forecast": {
                     "type": "number"
                 },
+                "forecastRaw": {
+                    "type": "string"
+                },
+                "forecastScale": {
+                    "type": "string"
+                },
+                "forecastUnit": {
+                    "type": "string"
+                },
+                "forecastValue": {
+                    "type": "number"
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -329,6 +585,18 @@ var doc = `{
                 "previous": {
                     "type": "number"
                 },
+                "previousRaw": {
+                    "type": "string"
+                },
+                "previousScale": {
+                    "type": "string"
+                },
+                "previousUnit": {
+                    "type": "string"
+                },
+                "previousValue": {
+                    "type": "number"
+                },
                 "source": {
                     "type": "string"
                 },
@@ -349,26 +617,234 @@ var doc = `{
                 }
             }
         },
+        "data.EventHistoryRow": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "type": "number"
+                },
+                "actualRaw": {
+                    "type": "string"
+                },
+                "actualRevisedAt": {
+                    "type": "string"
+                },
+                "actualRevisedRaw": {
+                    "type": "string"
+                },
+                "actualRevisedValue": {
+                    "type": "number"
+                },
+                "actualScale": {
+                    "type": "string"
+                },
+                "actualUnit": {
+                    "type": "string"
+                },
+                "actualValue": {
+                    "type": "number"
+                },
+                "eventId": {
+                    "type": "integer"
+                },
+                "forecast": {
+                    "type": "number"
+                },
+                "forecastRaw": {
+                    "type": "string"
+                },
+                "forecastScale": {
+                    "type": "string"
+                },
+                "forecastUnit": {
+                    "type": "string"
+                },
+                "forecastValue": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "previous": {
+                    "type": "number"
+                },
+                "previousFirstRaw": {
+                    "type": "string"
+                },
+                "previousFirstValue": {
+                    "type": "number"
+                },
+                "previousRaw": {
+                    "type": "string"
+                },
+                "previousScale": {
+                    "type": "string"
+                },
+                "previousUnit": {
+                    "type": "string"
+                },
+                "previousValue": {
+                    "type": "number"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
         "data.EventRow": {
             "type": "object",
             "properties": {
                 "actual": {
                     "type": "number"
                 },
+                "actualRaw": {
+                    "type": "string"
+                },
+                "actualScale": {
+                    "type": "string"
+                },
+                "actualUnit": {
+                    "type": "string"
+                },
+                "actualValue": {
+                    "type": "number"
+                },
+                "eventId": {
+                    "type": "integer"
+                },
+                "forecast": {
+                    "type": "number"
+                },
+                "forecastRaw": {
+                    "type": "string"
+                },
+                "forecastScale": {
+                    "type": "string"
+                },
+                "forecastUnit": {
+                    "type": "string"
+                },
+                "forecastValue": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "previous": {
+                    "type": "number"
+                },
+                "previousRaw": {
+                    "type": "string"
+                },
+                "previousScale": {
+                    "type": "string"
+                },
+                "previousUnit": {
+                    "type": "string"
+                },
+                "previousValue": {
+                    "type": "number"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.Holiday": {
+            "type": "object",
+            "properties": {
+                "countryCode": {
+                    "type": "string",
+                    "example": "KR"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2021-09-20T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 372
+                },
+                "title": {
+                    "type": "string",
+                    "example": "South Korea - Chuseok - Thanksgiving Day"
+                }
+            }
+        },
+        "data.ScheduleChange": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "type": "number"
+                },
+                "actualRaw": {
+                    "type": "string"
+                },
+                "actualScale": {
+                    "type": "string"
+                },
+                "actualUnit": {
+                    "type": "string"
+                },
+                "actualValue": {
+                    "type": "number"
+                },
+                "code": {
+                    "type": "string"
+                },
                 "eventId": {
                     "type": "integer"
                 },
                 "forecast": {
                     "type": "number"
                 },
+                "forecastRaw": {
+                    "type": "string"
+                },
+                "forecastScale": {
+                    "type": "string"
+                },
+                "forecastUnit": {
+                    "type": "string"
+                },
+                "forecastValue": {
+                    "type": "number"
+                },
                 "id": {
                     "type": "integer"
                 },
+                "impactLevel": {
+                    "type": "integer"
+                },
                 "previous": {
                     "type": "number"
                 },
+                "previousRaw": {
+                    "type": "string"
+                },
+                "previousScale": {
+                    "type": "string"
+                },
+                "previousUnit": {
+                    "type": "string"
+                },
+                "previousValue": {
+                    "type": "number"
+                },
                 "timestamp": {
                     "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "integer"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
                 }
             }
         },
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/denis-gudim/economic-calendar/api/httputil"
+	"github.com/denis-gudim/economic-calendar/api/v1/data"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultChangesLimit = 500
+	maxChangesLimit     = 5000
+)
+
+type ChangesDataReciver interface {
+	GetChanges(ctx context.Context, since data.ChangesCursor, limit int, langCode string) (*data.Changes, error)
+}
+
+type ChangesController struct {
+	repository ChangesDataReciver
+	logger     *zap.Logger
+}
+
+func NewChangesController(r ChangesDataReciver, l *zap.Logger) *ChangesController {
+	return &ChangesController{
+		repository: r,
+		logger:     l,
+	}
+}
+
+// GetChanges godoc
+// @Summary Schedule rows and events changed after cursor
+// @Schemes http|https
+// @Description Returns schedule rows and events modified after since cursor, next value of response is cursor for the following request, hasMore is set when the following request returns more changes immediately
+// @Tags Changes
+// @Accept json
+// @Produce json
+// @Param since query string true "next cursor of previous response or moment in RFC 3339 format for the first request e.g. 2021-10-10T12:00:00Z"
+// @Param limit query int false "max count of returned schedule rows and events" default(500) maximum(5000)
+// @Param lang query string false "language code value" default(en)
+// @Success 200 {object} data.Changes
+// @Failure 400 {object} httputil.BadRequestError
+// @Failure 500 {object} httputil.InternalServerError
+// @Router /changes [get]
+func (h *ChangesController) GetChanges(ctx *gin.Context) {
+
+	lang := ctx.DefaultQuery("lang", "en")
+	value := ctx.Query("since")
+
+	since, err := data.ParseChangesCursor(value)
+
+	if err != nil {
+		err = fmt.Errorf("invalid since value '%s': %w", value, err)
+		httputil.NewBadRequestError(ctx, err)
+		return
+	}
+
+	limit := defaultChangesLimit
+
+	if value := ctx.Query("limit"); len(value) > 0 {
+		limit, err = strconv.Atoi(value)
+
+		if err != nil || limit <= 0 || limit > maxChangesLimit {
+			err = fmt.Errorf("invalid limit value '%s': expected number from 1 to %d", value, maxChangesLimit)
+			httputil.NewBadRequestError(ctx, err)
+			return
+		}
+	}
+
+	changes, err := h.repository.GetChanges(ctx, since, limit, lang)
+
+	if err != nil {
+		h.logger.Error(err.Error(),
+			zap.String("since", since.String()),
+			zap.Int("limit", limit),
+			zap.String("lang", lang),
+		)
+		httputil.NewInternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, changes)
+}
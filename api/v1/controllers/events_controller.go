@@ -14,9 +14,9 @@ import (
 )
 
 type EventsDataReciver interface {
-	GetScheduleByDates(ctx context.Context, from, to time.Time, langCode string) ([]data.Event, error)
+	GetScheduleByDates(ctx context.Context, from, to time.Time, langCode string, asOf *time.Time) ([]data.Event, error)
 	GetEventById(ctx context.Context, eventId int, langCode string) (*data.EventDetails, error)
-	GetHistoryById(ctx context.Context, eventId int) ([]data.EventRow, error)
+	GetHistoryById(ctx context.Context, eventId int, asOf *time.Time) ([]data.EventHistoryRow, error)
 }
 
 type EventsController struct {
@@ -41,6 +41,7 @@ func NewEventsController(r EventsDataReciver, l *zap.Logger) *EventsController {
 // @Param from query string true "from date string in ISO 8601 format e.g. 2021-10-10"
 // @Param to query string true "to date string in ISO 8601 format e.g. 2021-10-10"
 // @Param lang query string false "language code value" default(en)
+// @Param asOf query string false "snapshot moment in RFC 3339 format e.g. 2021-10-10T12:00:00Z"
 // @Success 200 {array} data.Event
 // @Failure 400 {object} httputil.BadRequestError
 // @Failure 500 {object} httputil.InternalServerError
@@ -67,13 +68,21 @@ func (h *EventsController) GetEventsSchedule(ctx *gin.Context) {
 		return
 	}
 
-	rows, err := h.repository.GetScheduleByDates(ctx, fromDate, toDate, lang)
+	asOf, err := parseAsOf(ctx)
+
+	if err != nil {
+		httputil.NewBadRequestError(ctx, err)
+		return
+	}
+
+	rows, err := h.repository.GetScheduleByDates(ctx, fromDate, toDate, lang, asOf)
 
 	if err != nil {
 		h.logger.Error(err.Error(),
 			zap.Time("from", fromDate),
 			zap.Time("to", toDate),
 			zap.String("lang", lang),
+			zap.Timep("asOf", asOf),
 		)
 		httputil.NewInternalServerError(ctx, err)
 		return
@@ -132,12 +141,13 @@ func (h *EventsController) GetEventDetails(ctx *gin.Context) {
 // GetEventHistory godoc
 // @Summary Event history by id
 // @Schemes http|https
-// @Description Returns event history list by event id
+// @Description Returns event history list by event id with first-print and revised values of every release
 // @Tags Events
 // @Accept json
 // @Produce json
 // @Param eventId path int true "event identifier" example(368)
-// @Success 200 {array} data.EventRow
+// @Param asOf query string false "snapshot moment in RFC 3339 format e.g. 2021-10-10T12:00:00Z"
+// @Success 200 {array} data.EventHistoryRow
 // @Failure 400 {object} httputil.BadRequestError
 // @Failure 500 {object} httputil.InternalServerError
 // @Router /events/{eventId}/history [get]
@@ -153,11 +163,19 @@ func (h *EventsController) GetEventHistory(ctx *gin.Context) {
 		return
 	}
 
-	rows, err := h.repository.GetHistoryById(ctx, eventId)
+	asOf, err := parseAsOf(ctx)
+
+	if err != nil {
+		httputil.NewBadRequestError(ctx, err)
+		return
+	}
+
+	rows, err := h.repository.GetHistoryById(ctx, eventId, asOf)
 
 	if err != nil {
 		h.logger.Error(err.Error(),
 			zap.Int("eventId", eventId),
+			zap.Timep("asOf", asOf),
 		)
 		httputil.NewInternalServerError(ctx, err)
 		return
@@ -165,3 +183,21 @@ func (h *EventsController) GetEventHistory(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, rows)
 }
+
+func parseAsOf(ctx *gin.Context) (*time.Time, error) {
+	value, ok := ctx.GetQuery("asOf")
+
+	if !ok {
+		return nil, nil
+	}
+
+	asOf, err := time.Parse(time.RFC3339, value)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid as of value '%s': %w", value, err)
+	}
+
+	asOf = asOf.UTC()
+
+	return &asOf, nil
+}
@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/denis-gudim/economic-calendar/api/httputil"
+	"github.com/denis-gudim/economic-calendar/api/v1/data"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type HolidaysDataReciver interface {
+	GetHolidaysByDates(ctx context.Context, from, to time.Time, countryCode, langCode string) ([]data.Holiday, error)
+}
+
+type HolidaysController struct {
+	repository HolidaysDataReciver
+	logger     *zap.Logger
+}
+
+func NewHolidaysController(r HolidaysDataReciver, l *zap.Logger) *HolidaysController {
+	return &HolidaysController{
+		repository: r,
+		logger:     l,
+	}
+}
+
+// GetHolidays godoc
+// @Summary Holidays and bank closures between dates
+// @Schemes http|https
+// @Description Returns holidays and market closures list in dates diapasone
+// @Tags Holidays
+// @Accept json
+// @Produce json
+// @Param from query string true "from date string in ISO 8601 format e.g. 2021-10-10"
+// @Param to query string true "to date string in ISO 8601 format e.g. 2021-10-10"
+// @Param country query string false "country code value e.g. KR"
+// @Param lang query string false "language code value" default(en)
+// @Success 200 {array} data.Holiday
+// @Failure 400 {object} httputil.BadRequestError
+// @Failure 500 {object} httputil.InternalServerError
+// @Router /holidays [get]
+func (h *HolidaysController) GetHolidays(ctx *gin.Context) {
+
+	lang := ctx.DefaultQuery("lang", "en")
+	country := strings.ToUpper(ctx.Query("country"))
+	from := ctx.Query("from")
+	to := ctx.Query("to")
+
+	fromDate, err := time.ParseInLocation("2006-01-02", from, time.UTC)
+
+	if err != nil {
+		err = fmt.Errorf("invalid from date value '%s': %w", from, err)
+		httputil.NewBadRequestError(ctx, err)
+		return
+	}
+
+	toDate, err := time.ParseInLocation("2006-01-02", to, time.UTC)
+
+	if err != nil {
+		err = fmt.Errorf("invalid to date value '%s': %w", to, err)
+		httputil.NewBadRequestError(ctx, err)
+		return
+	}
+
+	rows, err := h.repository.GetHolidaysByDates(ctx, fromDate, toDate, country, lang)
+
+	if err != nil {
+		h.logger.Error(err.Error(),
+			zap.Time("from", fromDate),
+			zap.Time("to", toDate),
+			zap.String("country", country),
+			zap.String("lang", lang),
+		)
+		httputil.NewInternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rows)
+}
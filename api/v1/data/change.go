@@ -0,0 +1,88 @@
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	changeKindEvent    = 0
+	changeKindSchedule = 1
+)
+
+type ScheduleChange struct {
+	Event
+	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type EventChange struct {
+	Id          int       `json:"id"`
+	ImpactLevel int       `db:"impact_level" json:"impactLevel"`
+	Code        string    `json:"code"`
+	Unit        string    `json:"unit"`
+	Title       string    `json:"title"`
+	Overview    string    `json:"overview"`
+	Source      string    `json:"source"`
+	SourceUrl   string    `db:"source_url" json:"sourceUrl"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// Changes are schedule rows and events modified after since cursor, next is cursor
+// value for the following request. HasMore is set when changes are limited and the
+// following request returns more of them immediately.
+type Changes struct {
+	Since    string           `json:"since"`
+	Next     string           `json:"next"`
+	HasMore  bool             `json:"hasMore"`
+	Schedule []ScheduleChange `json:"schedule"`
+	Events   []EventChange    `json:"events"`
+}
+
+// ChangesCursor is position in changes feed. Changes are ordered by id of writing transaction,
+// kind and row id. Cursor of the first request is moment in time, changes made after it are
+// returned from the feed start.
+type ChangesCursor struct {
+	Time     time.Time
+	ChangeId int64
+	Kind     int
+	Id       int
+}
+
+// ParseChangesCursor parses cursor returned by changes feed or moment in RFC 3339 format.
+func ParseChangesCursor(value string) (ChangesCursor, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return ChangesCursor{Time: t.UTC()}, nil
+	}
+
+	parts := strings.Split(value, ".")
+
+	if len(parts) != 3 {
+		return ChangesCursor{}, fmt.Errorf("invalid cursor value '%s': expected RFC 3339 time or changes cursor", value)
+	}
+
+	changeId, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return ChangesCursor{}, fmt.Errorf("invalid cursor value '%s': %w", value, err)
+	}
+
+	kind, err := strconv.Atoi(parts[1])
+	if err != nil || kind < changeKindEvent || kind > changeKindSchedule {
+		return ChangesCursor{}, fmt.Errorf("invalid cursor value '%s': unknown change kind", value)
+	}
+
+	id, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return ChangesCursor{}, fmt.Errorf("invalid cursor value '%s': %w", value, err)
+	}
+
+	return ChangesCursor{ChangeId: changeId, Kind: kind, Id: id}, nil
+}
+
+func (c ChangesCursor) String() string {
+	if c.ChangeId == 0 && !c.Time.IsZero() {
+		return c.Time.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprintf("%d.%d.%d", c.ChangeId, c.Kind, c.Id)
+}
@@ -0,0 +1,132 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type ChangesRepository struct {
+	Db *sqlx.DB
+}
+
+func NewChangesRepository(db *sqlx.DB) *ChangesRepository {
+	return &ChangesRepository{db}
+}
+
+type changeKey struct {
+	ChangeId int64 `db:"change_xid"`
+	Kind     int   `db:"kind"`
+	Id       int   `db:"id"`
+}
+
+// GetChanges returns up to limit schedule rows and events changed after since cursor. Rows
+// are ordered by id of transaction which changed them and only rows of transactions older
+// than any running one are returned, so rows committed later can't appear behind cursor.
+func (r *ChangesRepository) GetChanges(ctx context.Context, since ChangesCursor, limit int, langCode string) (*Changes, error) {
+	changes := Changes{
+		Since:    since.String(),
+		Next:     since.String(),
+		Schedule: make([]ScheduleChange, 0, 128),
+		Events:   make([]EventChange, 0, 16),
+	}
+
+	// all selects are done in one snapshot, so schedule rows and events are consistent
+	tx, err := r.Db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("get changes begin transaction error: %w", err)
+	}
+	defer tx.Rollback()
+
+	// transactions with lower ids are completed, their changes are visible or rolled back
+	var xmin int64
+
+	err = tx.GetContext(ctx, &xmin, `SELECT txid_snapshot_xmin(txid_current_snapshot())`)
+	if err != nil {
+		return nil, fmt.Errorf("get changes snapshot error: %w", err)
+	}
+
+	keys := make([]changeKey, 0, limit+1)
+
+	err = tx.SelectContext(ctx, &keys,
+		`SELECT c.change_xid, c.kind, c.id FROM
+		 (SELECT e.change_xid, $1::integer AS kind, e.id FROM events AS e
+		  WHERE e.change_xid >= $3 AND e.change_xid < $7 AND e.updated_at > $8::timestamp
+		  UNION ALL
+		  SELECT es.change_xid, $2::integer AS kind, es.id FROM event_schedule AS es
+		  WHERE es.change_xid >= $3 AND es.change_xid < $7 AND es.updated_at > $8::timestamp) AS c
+		 WHERE (c.change_xid, c.kind, c.id) > ($3, $4, $5)
+		 ORDER BY c.change_xid, c.kind, c.id
+		 LIMIT $6`,
+		changeKindEvent, changeKindSchedule, since.ChangeId, since.Kind, since.Id, limit+1, xmin, since.Time)
+	if err != nil {
+		return nil, fmt.Errorf("get changes keys error: %w", err)
+	}
+
+	if len(keys) > limit {
+		keys = keys[:limit]
+		changes.HasMore = true
+	}
+
+	scheduleIds := make([]int64, 0, len(keys))
+	eventIds := make([]int64, 0, len(keys))
+
+	for _, key := range keys {
+		if key.Kind == changeKindSchedule {
+			scheduleIds = append(scheduleIds, int64(key.Id))
+		} else {
+			eventIds = append(eventIds, int64(key.Id))
+		}
+	}
+
+	if len(scheduleIds) > 0 {
+		err = tx.SelectContext(ctx, &changes.Schedule,
+			`SELECT es.id, es.event_id, es.type, e.impact_level, COALESCE(c.code, '') AS code, es.timestamp_utc, COALESCE(est.title, '') AS title, es.actual, es.forecast, es.previous, COALESCE(e.unit, '') AS unit,
+			 es.actual_raw, es.actual_value, es.actual_scale, es.actual_unit,
+			 es.forecast_raw, es.forecast_value, es.forecast_scale, es.forecast_unit,
+			 es.previous_raw, es.previous_value, es.previous_scale, es.previous_unit,
+			 es.updated_at
+			 FROM event_schedule AS es JOIN events AS e
+			 ON e.id = es.event_id LEFT JOIN countries AS c
+			 ON c.id = e.country_id LEFT JOIN (event_schedule_translations AS est JOIN languages AS l
+			 ON l.id = est.language_id AND l.code = $1)
+			 ON es.id = est.event_schedule_id
+			 WHERE es.id = ANY($2)
+			 ORDER BY es.change_xid, es.id`, langCode, pq.Array(scheduleIds))
+		if err != nil {
+			return nil, fmt.Errorf("get schedule changes error: %w", err)
+		}
+	}
+
+	if len(eventIds) > 0 {
+		err = tx.SelectContext(ctx, &changes.Events,
+			`SELECT e.id, e.impact_level, COALESCE(c.code, '') AS code, COALESCE(e.unit, '') AS unit,
+			 COALESCE(et.title, '') AS title, COALESCE(et.overview, '') AS overview,
+			 COALESCE(e.source, '') AS source, COALESCE(e.source_url, '') AS source_url,
+			 e.updated_at
+			 FROM events AS e LEFT JOIN countries AS c
+			 ON c.id = e.country_id LEFT JOIN (event_translations AS et JOIN languages AS l
+			 ON l.id = et.language_id AND l.code = $1)
+			 ON e.id = et.event_id
+			 WHERE e.id = ANY($2)
+			 ORDER BY e.change_xid, e.id`, langCode, pq.Array(eventIds))
+		if err != nil {
+			return nil, fmt.Errorf("get event changes error: %w", err)
+		}
+	}
+
+	switch {
+	case changes.HasMore:
+		last := keys[len(keys)-1]
+		changes.Next = ChangesCursor{ChangeId: last.ChangeId, Kind: last.Kind, Id: last.Id}.String()
+	case xmin > since.ChangeId:
+		// every change of completed transactions is returned, feed continues from the oldest
+		// running one, row ids start from 1, so no change of it is skipped
+		changes.Next = ChangesCursor{ChangeId: xmin, Kind: changeKindEvent}.String()
+	}
+
+	return &changes, nil
+}
@@ -0,0 +1,15 @@
+package data
+
+import "time"
+
+// EventHistoryRow is a release with its first-print and revised figures. Actual value
+// of release is first print, revised value is taken from revision of previous figure
+// detected on the following release.
+type EventHistoryRow struct {
+	EventRow
+	PreviousFirstRaw   *string    `db:"previous_first_raw" json:"previousFirstRaw"`
+	PreviousFirstValue *float64   `db:"previous_first_value" json:"previousFirstValue"`
+	ActualRevisedRaw   *string    `db:"actual_revised_raw" json:"actualRevisedRaw"`
+	ActualRevisedValue *float64   `db:"actual_revised_value" json:"actualRevisedValue"`
+	ActualRevisedAt    *time.Time `db:"actual_revised_at" json:"actualRevisedAt"`
+}
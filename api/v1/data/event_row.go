@@ -9,4 +9,17 @@ type EventRow struct {
 	Actual    *float64  `json:"actual"`
 	Forecast  *float64  `json:"forecast"`
 	Previous  *float64  `json:"previous"`
+
+	ActualRaw     *string  `db:"actual_raw" json:"actualRaw"`
+	ActualValue   *float64 `db:"actual_value" json:"actualValue"`
+	ActualScale   *string  `db:"actual_scale" json:"actualScale"`
+	ActualUnit    *string  `db:"actual_unit" json:"actualUnit"`
+	ForecastRaw   *string  `db:"forecast_raw" json:"forecastRaw"`
+	ForecastValue *float64 `db:"forecast_value" json:"forecastValue"`
+	ForecastScale *string  `db:"forecast_scale" json:"forecastScale"`
+	ForecastUnit  *string  `db:"forecast_unit" json:"forecastUnit"`
+	PreviousRaw   *string  `db:"previous_raw" json:"previousRaw"`
+	PreviousValue *float64 `db:"previous_value" json:"previousValue"`
+	PreviousScale *string  `db:"previous_scale" json:"previousScale"`
+	PreviousUnit  *string  `db:"previous_unit" json:"previousUnit"`
 }
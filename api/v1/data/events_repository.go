@@ -16,17 +16,24 @@ func NewEventsRepository(db *sqlx.DB) *EventsRepository {
 	return &EventsRepository{db}
 }
 
-func (r *EventsRepository) GetScheduleByDates(ctx context.Context, from, to time.Time, langCode string) ([]Event, error) {
+func (r *EventsRepository) GetScheduleByDates(ctx context.Context, from, to time.Time, langCode string, asOf *time.Time) ([]Event, error) {
 	rows := make([]Event, 0, 128)
+	args := []interface{}{langCode, from, to}
+	if asOf != nil {
+		args = append(args, *asOf)
+	}
 	err := r.Db.SelectContext(ctx, &rows,
-		`SELECT es.id, es.event_id, es.type, e.impact_level, c.code, es.timestamp_utc, est.title, es.actual, es.forecast, es.previous, e.unit
-		 FROM event_schedule AS es JOIN events AS e 
+		`SELECT es.id, es.event_id, es.type, e.impact_level, c.code, es.timestamp_utc, est.title, es.actual, es.forecast, es.previous, e.unit,
+		 es.actual_raw, es.actual_value, es.actual_scale, es.actual_unit,
+		 es.forecast_raw, es.forecast_value, es.forecast_scale, es.forecast_unit,
+		 es.previous_raw, es.previous_value, es.previous_scale, es.previous_unit
+		 FROM `+scheduleSource(asOf, 4)+` AS es JOIN events AS e 
 		 ON e.id = es.event_id JOIN countries AS c
 		 ON c.id = e.country_id JOIN event_schedule_translations AS est
 		 ON es.id = est.event_schedule_id JOIN languages AS l
 		 ON l.id = est.language_id and l.code = $1
 		 WHERE es.timestamp_utc >= $2::timestamp AND es.timestamp_utc < $3::timestamp
-		 ORDER BY es.timestamp_utc DESC`, langCode, from, to)
+		 ORDER BY es.timestamp_utc DESC`, args...)
 	if err != nil {
 		return nil, fmt.Errorf("get schedule by dates error: %w", err)
 	}
@@ -36,7 +43,10 @@ func (r *EventsRepository) GetScheduleByDates(ctx context.Context, from, to time
 func (r *EventsRepository) GetEventById(ctx context.Context, eventId int, langCode string) (*EventDetails, error) {
 	rows := make([]EventDetails, 0, 1)
 	err := r.Db.SelectContext(ctx, &rows,
-		`SELECT es.id, es.event_id, es.type, e.impact_level, c.code, es.timestamp_utc, et.title, es.actual, es.forecast, es.previous, et.overview, e.source, e.source_url, e.unit
+		`SELECT es.id, es.event_id, es.type, e.impact_level, c.code, es.timestamp_utc, et.title, es.actual, es.forecast, es.previous, et.overview, e.source, e.source_url, e.unit,
+		 es.actual_raw, es.actual_value, es.actual_scale, es.actual_unit,
+		 es.forecast_raw, es.forecast_value, es.forecast_scale, es.forecast_unit,
+		 es.previous_raw, es.previous_value, es.previous_scale, es.previous_unit
 		 FROM event_schedule AS es JOIN events AS e 
 		 ON e.id = es.event_id AND e.id = $1 JOIN countries AS c
 		 ON c.id = e.country_id JOIN event_translations AS et
@@ -53,15 +63,45 @@ func (r *EventsRepository) GetEventById(ctx context.Context, eventId int, langCo
 	return &rows[0], nil
 }
 
-func (r *EventsRepository) GetHistoryById(ctx context.Context, eventId int) ([]EventRow, error) {
-	rows := make([]EventRow, 0, 128)
+func (r *EventsRepository) GetHistoryById(ctx context.Context, eventId int, asOf *time.Time) ([]EventHistoryRow, error) {
+	rows := make([]EventHistoryRow, 0, 128)
+	args := []interface{}{eventId}
+	revisionsFilter := ""
+	if asOf != nil {
+		args = append(args, *asOf)
+		revisionsFilter = "AND detected_at <= $2"
+	}
 	err := r.Db.SelectContext(ctx, &rows,
-		`SELECT id, event_id, timestamp_utc, actual, forecast, previous
-		 FROM event_schedule
-		 WHERE event_id = $1
-		 ORDER BY timestamp_utc DESC`, eventId)
+		`SELECT es.id, es.event_id, es.timestamp_utc, es.actual, es.forecast, es.previous,
+		 es.actual_raw, es.actual_value, es.actual_scale, es.actual_unit,
+		 es.forecast_raw, es.forecast_value, es.forecast_scale, es.forecast_unit,
+		 es.previous_raw, es.previous_value, es.previous_scale, es.previous_unit,
+		 es.previous_first_raw, es.previous_first_value,
+		 r.revised_raw AS actual_revised_raw, r.revised_value AS actual_revised_value, r.detected_at AS actual_revised_at
+		 FROM (SELECT *, LEAD(id) OVER (ORDER BY timestamp_utc) AS next_id
+		       FROM `+scheduleSource(asOf, 2)+` AS s
+		       WHERE event_id = $1) AS es
+		 LEFT JOIN LATERAL (SELECT revised_raw, revised_value, detected_at
+		       FROM event_schedule_revisions
+		       WHERE event_schedule_id = es.next_id `+revisionsFilter+`
+		       ORDER BY detected_at DESC
+		       LIMIT 1) AS r ON TRUE
+		 ORDER BY es.timestamp_utc DESC`, args...)
 	if err != nil {
 		return nil, fmt.Errorf("get history by id error: %w", err)
 	}
 	return rows, nil
 }
+
+// scheduleSource returns event schedule table expression. When asOf is specified
+// schedule is answered from versions valid at that moment, asOf is passed as
+// parameter with specified index.
+func scheduleSource(asOf *time.Time, param int) string {
+	if asOf == nil {
+		return "event_schedule"
+	}
+	return fmt.Sprintf(
+		`(SELECT v.*, v.event_schedule_id AS id
+		  FROM event_schedule_versions AS v
+		  WHERE v.valid_from <= $%[1]d::timestamp AND (v.valid_to IS NULL OR v.valid_to > $%[1]d::timestamp))`, param)
+}
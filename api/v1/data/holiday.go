@@ -0,0 +1,10 @@
+package data
+
+import "time"
+
+type Holiday struct {
+	Id          int       `json:"id" example:"372"`
+	CountryCode string    `db:"code" json:"countryCode" example:"KR"`
+	Date        time.Time `json:"date" example:"2021-09-20T00:00:00Z"`
+	Title       string    `json:"title" example:"South Korea - Chuseok - Thanksgiving Day"`
+}
@@ -0,0 +1,33 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type HolidaysRepository struct {
+	Db *sqlx.DB
+}
+
+func NewHolidaysRepository(db *sqlx.DB) *HolidaysRepository {
+	return &HolidaysRepository{db}
+}
+
+func (r *HolidaysRepository) GetHolidaysByDates(ctx context.Context, from, to time.Time, countryCode, langCode string) ([]Holiday, error) {
+	rows := make([]Holiday, 0, 32)
+	err := r.Db.SelectContext(ctx, &rows,
+		`SELECT h.id, c.code, h.date, ht.title
+		 FROM holidays AS h JOIN countries AS c
+		 ON c.id = h.country_id JOIN holiday_translations AS ht
+		 ON h.id = ht.holiday_id JOIN languages AS l
+		 ON l.id = ht.language_id AND l.code = $1
+		 WHERE h.date >= $2::date AND h.date < $3::date AND ($4 = '' OR c.code = $4)
+		 ORDER BY h.date DESC, c.code`, langCode, from, to, countryCode)
+	if err != nil {
+		return nil, fmt.Errorf("get holidays by dates error: %w", err)
+	}
+	return rows, nil
+}
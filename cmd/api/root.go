@@ -67,6 +67,18 @@ func NewCompositionRoot() (*CompositionRoot, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = container.Provide(func(db *sqlx.DB) v1_controllers.HolidaysDataReciver {
+		return v1_data.NewHolidaysRepository(db)
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = container.Provide(func(db *sqlx.DB) v1_controllers.ChangesDataReciver {
+		return v1_data.NewChangesRepository(db)
+	})
+	if err != nil {
+		return nil, err
+	}
 	err = container.Provide(v1_controllers.NewCountriesController)
 	if err != nil {
 		return nil, err
@@ -75,6 +87,14 @@ func NewCompositionRoot() (*CompositionRoot, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = container.Provide(v1_controllers.NewHolidaysController)
+	if err != nil {
+		return nil, err
+	}
+	err = container.Provide(v1_controllers.NewChangesController)
+	if err != nil {
+		return nil, err
+	}
 	err = container.Provide(NewHealtz)
 	if err != nil {
 		return nil, err
@@ -112,6 +132,26 @@ func (r *CompositionRoot) InitHttpServer(gin *gin.Engine) error {
 		return fmt.Errorf("events controller init error: %w", err)
 	}
 
+	err = r.container.Invoke(func(c *v1_controllers.HolidaysController) {
+		g := v1.Group("holidays")
+
+		g.GET("", c.GetHolidays)
+	})
+
+	if err != nil {
+		return fmt.Errorf("holidays controller init error: %w", err)
+	}
+
+	err = r.container.Invoke(func(c *v1_controllers.ChangesController) {
+		g := v1.Group("changes")
+
+		g.GET("", c.GetChanges)
+	})
+
+	if err != nil {
+		return fmt.Errorf("changes controller init error: %w", err)
+	}
+
 	err = r.container.Invoke(func(c *Healtz) {
 		gin.GET("/healtz", c.Handle)
 	})
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/denis-gudim/economic-calendar/loader/investing/emulator"
+	log "github.com/sirupsen/logrus"
+)
+
+func main() {
+	addr := flag.String("addr", ":8090", "http server listen address")
+	datasetPath := flag.String("dataset", "dataset.json", "fixture dataset file path")
+	flag.Parse()
+
+	dataset, err := emulator.LoadDataset(*datasetPath)
+	if err != nil {
+		err = fmt.Errorf("load emulator dataset failed: %w", err)
+		processError(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{
+		Addr:    *addr,
+		Handler: emulator.NewServer(dataset),
+	}
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			err = fmt.Errorf("listen http server failed: %w", err)
+			processError(err)
+		}
+	}()
+
+	log.Infof("investing emulator started on '%s'...", *addr)
+
+	<-ctx.Done()
+
+	stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		err = fmt.Errorf("http server forced to shutdown: %w", err)
+		processError(err)
+	}
+}
+
+func processError(err error) {
+	log.Fatal(err)
+	os.Exit(2)
+}
@@ -0,0 +1,340 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/denis-gudim/economic-calendar/loader/investing"
+	"github.com/denis-gudim/economic-calendar/loader/loading"
+	"go.uber.org/dig"
+)
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, container *dig.Container, args []string) error
+}
+
+var commands = map[string]command{
+	"backfill": {
+		usage: "backfill -from YYYY-MM-DD [-to YYYY-MM-DD] [-lang en,de] - load history of specified days and languages",
+		run:   runBackfillCommand,
+	},
+	"canary": {
+		usage: "canary [-update-baseline] - check parsers against canary day and event, optionally store current coverage as baseline",
+		run:   runCanaryCommand,
+	},
+	"coverage": {
+		usage: "coverage [-from YYYY-MM-DD] [-to YYYY-MM-DD] [-reload] - report gaps of stored history and optionally reload affected days",
+		run:   runCoverageCommand,
+	},
+	"quarantine": {
+		usage: "quarantine [-reparse] [-remove] - list documents failed to parse, reparse them with current parser and optionally remove parsed ones",
+		run:   runQuarantineCommand,
+	},
+	"refresh-now": {
+		usage: "refresh-now - refresh events schedule around current time once",
+		run:   runRefreshNowCommand,
+	},
+	"reload-countries": {
+		usage: "reload-countries - reload countries translations regardless of stored ones",
+		run:   runReloadCountriesCommand,
+	},
+	"reload-event": {
+		usage: "reload-event <id> - reload event details in all languages",
+		run:   runReloadEventCommand,
+	},
+	"verify": {
+		usage: "verify - run canary check against stored baseline and healthchecks, fails when any of them fails or baseline is missing",
+		run:   runVerifyCommand,
+	},
+}
+
+// runCommand executes loader subcommand instead of starting scheduler and http server.
+func runCommand(ctx context.Context, container *dig.Container, args []string) error {
+	cmd, ok := commands[args[0]]
+
+	if !ok {
+		printUsage()
+		return fmt.Errorf("unknown command '%s'", args[0])
+	}
+
+	return cmd.run(ctx, container, args[1:])
+}
+
+func printUsage() {
+	names := make([]string, 0, len(commands))
+
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "usage: loader [command] [flags]")
+	fmt.Fprintln(os.Stderr, "runs scheduler when command is not specified, commands:")
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s\n", commands[name].usage)
+	}
+}
+
+type dateFlag struct {
+	time.Time
+}
+
+func (f *dateFlag) String() string {
+	if f.IsZero() {
+		return ""
+	}
+	return f.Format("2006-01-02")
+}
+
+func (f *dateFlag) Set(value string) (err error) {
+	f.Time, err = time.ParseInLocation("2006-01-02", value, time.UTC)
+	return
+}
+
+// languagesFlag is comma separated list of language codes or ids.
+type languagesFlag []int
+
+func (f *languagesFlag) String() string {
+	values := make([]string, len(*f))
+	for i, languageId := range *f {
+		values[i] = strconv.Itoa(languageId)
+	}
+	return strings.Join(values, ",")
+}
+
+func (f *languagesFlag) Set(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+
+		if languageId, err := strconv.Atoi(item); err == nil {
+			if _, ok := investing.InvestingLanguagesMap[languageId]; ok {
+				*f = append(*f, languageId)
+				continue
+			}
+		}
+
+		languageId, ok := languageIdByCode(item)
+		if !ok {
+			return fmt.Errorf("unknown language '%s'", item)
+		}
+
+		*f = append(*f, languageId)
+	}
+	return nil
+}
+
+func languageIdByCode(code string) (int, bool) {
+	for id, lang := range investing.InvestingLanguagesMap {
+		if strings.EqualFold(lang.Code, code) {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+func runBackfillCommand(ctx context.Context, container *dig.Container, args []string) error {
+	var (
+		from, to  dateFlag
+		languages languagesFlag
+	)
+
+	flags := flag.NewFlagSet("backfill", flag.ContinueOnError)
+	flags.Var(&from, "from", "first loaded day")
+	flags.Var(&to, "to", "last loaded day, defaults to -from")
+	flags.Var(&languages, "lang", "comma separated language codes or ids, defaults to all languages")
+
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if from.IsZero() {
+		return fmt.Errorf("backfill: -from is required")
+	}
+
+	if to.IsZero() {
+		to = from
+	}
+
+	if to.Before(from.Time) {
+		return fmt.Errorf("backfill: -to %s is before -from %s", to.String(), from.String())
+	}
+
+	targets := make(map[time.Time][]int)
+
+	for day := from.Time; !day.After(to.Time); day = day.AddDate(0, 0, 1) {
+		targets[day] = languages
+	}
+
+	return container.Invoke(func(s *loading.HistoryLoaderService) error {
+		return s.LoadDays(ctx, targets)
+	})
+}
+
+func runReloadEventCommand(ctx context.Context, container *dig.Container, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("reload-event: event id is required")
+	}
+
+	eventId, err := strconv.Atoi(args[0])
+	if err != nil || eventId <= 0 {
+		return fmt.Errorf("reload-event: invalid event id '%s'", args[0])
+	}
+
+	return container.Invoke(func(s *loading.HistoryLoaderService) error {
+		return s.ReloadEvent(ctx, eventId)
+	})
+}
+
+func runReloadCountriesCommand(ctx context.Context, container *dig.Container, args []string) error {
+	return container.Invoke(func(s *loading.DictionariesLoaderService) error {
+		return s.Reload(ctx)
+	})
+}
+
+func runRefreshNowCommand(ctx context.Context, container *dig.Container, args []string) error {
+	return container.Invoke(func(s *loading.RefreshCalendarService) error {
+		_, err := s.Refresh(ctx)
+		return err
+	})
+}
+
+func runVerifyCommand(ctx context.Context, container *dig.Container, args []string) error {
+	return container.Invoke(func(c *loading.CanaryService, h *Healtz) error {
+		report := c.Verify(ctx)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/healtz", nil)
+		if err != nil {
+			return err
+		}
+
+		items := make(map[string]interface{})
+		failed := h.runChecks(req, items)
+
+		if err = writeJson(items); err != nil {
+			return fmt.Errorf("write verify report error: %w", err)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("verify failed: %d checks failed", failed)
+		}
+
+		if len(report.SourceError) > 0 {
+			return fmt.Errorf("verify failed: canary documents loading failed: %s", report.SourceError)
+		}
+
+		return nil
+	})
+}
+
+func runCoverageCommand(ctx context.Context, container *dig.Container, args []string) error {
+	var (
+		from, to dateFlag
+		reload   bool
+	)
+
+	flags := flag.NewFlagSet("coverage", flag.ContinueOnError)
+	flags.Var(&from, "from", "first day of analysed history, defaults to LOADING_FROMTIME")
+	flags.Var(&to, "to", "last day of analysed history, defaults to today")
+	flags.BoolVar(&reload, "reload", false, "reload affected days and events after analysis")
+
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return container.Invoke(func(s *loading.CoverageService) error {
+		report, err := s.Analyse(ctx, from.Time, to.Time)
+
+		if err != nil {
+			return err
+		}
+
+		if err = writeJson(report); err != nil {
+			return fmt.Errorf("write coverage report error: %w", err)
+		}
+
+		if !reload || report.IsEmpty() {
+			return nil
+		}
+
+		return s.Reload(ctx, report)
+	})
+}
+
+func runQuarantineCommand(ctx context.Context, container *dig.Container, args []string) error {
+	var reparse, remove bool
+
+	flags := flag.NewFlagSet("quarantine", flag.ContinueOnError)
+	flags.BoolVar(&reparse, "reparse", false, "run current parser over quarantined documents")
+	flags.BoolVar(&remove, "remove", false, "remove documents parsed successfully, requires -reparse")
+
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return container.Invoke(func(q *investing.InvestingQuarantine) error {
+		if !reparse {
+			items, err := q.List()
+			if err != nil {
+				return err
+			}
+			return writeJson(items)
+		}
+
+		results, err := q.ReparseAll(remove)
+		if err != nil {
+			return err
+		}
+
+		return writeJson(results)
+	})
+}
+
+func runCanaryCommand(ctx context.Context, container *dig.Container, args []string) error {
+	var update bool
+
+	flags := flag.NewFlagSet("canary", flag.ContinueOnError)
+	flags.BoolVar(&update, "update-baseline", false, "store current canary coverage as baseline")
+
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return container.Invoke(func(s *loading.CanaryService) error {
+		report := s.Check(ctx, update)
+
+		if err := writeJson(report); err != nil {
+			return fmt.Errorf("write canary report error: %w", err)
+		}
+
+		if !report.IsHealthy() {
+			return fmt.Errorf("canary check failed")
+		}
+
+		return nil
+	})
+}
+
+func writeJson(value interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/denis-gudim/economic-calendar/loader/loading"
+	"github.com/sirupsen/logrus"
+)
+
+// CoverageHandler serves coverage report of stored history. GET returns report, POST
+// additionally starts background reload of affected days and events. Both require admin
+// token as bearer authorization, since report scans whole stored history, and are disabled
+// when token isn't configured.
+type CoverageHandler struct {
+	ctx       context.Context
+	service   *loading.CoverageService
+	logger    *logrus.Logger
+	token     string
+	reloading int32
+}
+
+func NewCoverageHandler(ctx context.Context, service *loading.CoverageService, logger *logrus.Logger, token string) *CoverageHandler {
+	return &CoverageHandler{ctx: ctx, service: service, logger: logger, token: token}
+}
+
+func (h *CoverageHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.isAuthorized(req) {
+		if len(h.token) == 0 {
+			http.Error(w, "coverage endpoint is disabled", http.StatusForbidden)
+			return
+		}
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	from, err := parseDateQuery(req, "from")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	to, err := parseDateQuery(req, "to")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.Analyse(req.Context(), from, to)
+	if err != nil {
+		h.logger.Error(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	status := http.StatusOK
+
+	if req.Method == http.MethodPost && !report.IsEmpty() {
+		if !atomic.CompareAndSwapInt32(&h.reloading, 0, 1) {
+			http.Error(w, "coverage reload is already running", http.StatusConflict)
+			return
+		}
+
+		go func() {
+			defer atomic.StoreInt32(&h.reloading, 0)
+
+			if err := h.service.Reload(h.ctx, report); err != nil {
+				h.logger.Error(err)
+			}
+		}()
+
+		status = http.StatusAccepted
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		h.logger.Error(err)
+	}
+}
+
+func (h *CoverageHandler) isAuthorized(req *http.Request) bool {
+	if len(h.token) == 0 {
+		return false
+	}
+
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) == 1
+}
+
+func parseDateQuery(req *http.Request, name string) (time.Time, error) {
+	value := req.URL.Query().Get(name)
+
+	if len(value) == 0 {
+		return time.Time{}, nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value, time.UTC)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s date value '%s': %w", name, value, err)
+	}
+
+	return date, nil
+}
@@ -7,20 +7,26 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/denis-gudim/economic-calendar/loader/investing"
+	"github.com/denis-gudim/economic-calendar/loader/loading"
 	"github.com/sirupsen/logrus"
 )
 
 type Healtz struct {
 	db     *sql.DB
+	source *investing.InvestingCircuitBreaker
+	canary *loading.CanaryService
 	logger *logrus.Logger
 	checks map[string]func(req *http.Request) (interface{}, error)
 }
 
-func NewHealtz(db *sql.DB, logger *logrus.Logger) *Healtz {
-	h := Healtz{db: db, logger: logger}
+func NewHealtz(db *sql.DB, source *investing.InvestingCircuitBreaker, canary *loading.CanaryService, logger *logrus.Logger) *Healtz {
+	h := Healtz{db: db, source: source, canary: canary, logger: logger}
 
 	h.checks = map[string]func(req *http.Request) (interface{}, error){
-		"db": h.checkDB,
+		"db":     h.checkDB,
+		"source": h.checkSource,
+		"canary": h.checkCanary,
 	}
 
 	return &h
@@ -37,19 +43,9 @@ func (h *Healtz) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		Items:  make(map[string]interface{}),
 	}
 
-	failed := 0
 	start := time.Now()
 
-	for k, v := range h.checks {
-		res, err := v(req)
-
-		if err != nil {
-			failed++
-			h.logger.Error(err)
-		}
-
-		out.Items[k] = res
-	}
+	failed := h.runChecks(req, out.Items)
 
 	out.Duration = time.Since(start).String()
 	out.Failed = failed
@@ -68,6 +64,22 @@ func (h *Healtz) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// runChecks runs every check and stores its result into items, returns failed checks count.
+func (h *Healtz) runChecks(req *http.Request, items map[string]interface{}) (failed int) {
+	for k, v := range h.checks {
+		res, err := v(req)
+
+		if err != nil {
+			failed++
+			h.logger.Error(err)
+		}
+
+		items[k] = res
+	}
+
+	return
+}
+
 func (h *Healtz) checkDB(req *http.Request) (interface{}, error) {
 	out := struct {
 		Status   string      `json:"status"`
@@ -95,3 +107,42 @@ func (h *Healtz) checkDB(req *http.Request) (interface{}, error) {
 
 	return out, err
 }
+
+func (h *Healtz) checkSource(req *http.Request) (interface{}, error) {
+	out := struct {
+		Status  string                         `json:"status"`
+		Breaker investing.CircuitBreakerStatus `json:"breaker"`
+	}{
+		Status:  "UP",
+		Breaker: h.source.Status(),
+	}
+
+	if out.Breaker.State == investing.CircuitOpen {
+		out.Status = "DOWN"
+		return out, fmt.Errorf("source healthcheck err: %w: %s", investing.ErrSourceUnavailable, out.Breaker.LastError)
+	}
+
+	return out, nil
+}
+
+func (h *Healtz) checkCanary(req *http.Request) (interface{}, error) {
+	out := struct {
+		Status string                `json:"status"`
+		Report *loading.CanaryReport `json:"report,omitempty"`
+	}{
+		Status: "UP",
+		Report: h.canary.LastReport(),
+	}
+
+	if out.Report == nil || out.Report.IsHealthy() {
+		return out, nil
+	}
+
+	out.Status = "DOWN"
+
+	if len(out.Report.Error) > 0 {
+		return out, fmt.Errorf("canary healthcheck err: %s", out.Report.Error)
+	}
+
+	return out, fmt.Errorf("canary healthcheck err: parser drift detected in %d metrics", len(out.Report.Drifts))
+}
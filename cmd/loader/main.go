@@ -26,6 +26,14 @@ func main() {
 	defer stop()
 
 	container := root.GetContainer()
+
+	if len(os.Args) > 1 {
+		if err = runCommand(ctx, container, os.Args[1:]); err != nil {
+			processError(err)
+		}
+		return
+	}
+
 	err = container.Invoke(func(s *loading.DictionariesLoaderService) error {
 		return s.Load(ctx)
 	})
@@ -43,7 +51,7 @@ func main() {
 		Addr:    ":8080",
 		Handler: http.DefaultServeMux,
 	}
-	if err = root.InitHttpServer(); err != nil {
+	if err = root.InitHttpServer(ctx); err != nil {
 		err = fmt.Errorf("init http server failed: %w", err)
 		processError(err)
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/denis-gudim/economic-calendar/loader/loading"
 	"github.com/go-co-op/gocron"
 	_ "github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 	"go.uber.org/dig"
 )
@@ -19,6 +20,7 @@ import (
 type CompositionRoot struct {
 	logger    *logrus.Logger
 	db        *sql.DB
+	dryRun    *data.DryRunWriter
 	container *dig.Container
 	cnf       *loader.Config
 }
@@ -39,6 +41,15 @@ func NewCompositionRoot() (*CompositionRoot, error) {
 		return nil, fmt.Errorf("connect to database error: %w", err)
 	}
 
+	var dryRun *data.DryRunWriter
+
+	if cnf.Loading.DryRun {
+		if dryRun, err = data.NewDryRunWriter(db, cnf.Loading.DryRunOutput); err != nil {
+			return nil, err
+		}
+		logger.Warn("dry run mode: loaded data is written as JSON Lines instead of database")
+	}
+
 	err = container.Provide(func() *loader.Config {
 		return cnf
 	})
@@ -57,42 +68,105 @@ func NewCompositionRoot() (*CompositionRoot, error) {
 	if err != nil {
 		return nil, err
 	}
-	err = container.Provide(func(c *loader.Config) investing.InvestingHtmlSource {
-		return investing.NewInvestingHttpClient(c)
+	err = container.Provide(newInvestingCircuitBreaker)
+	if err != nil {
+		return nil, err
+	}
+	err = container.Provide(func(b *investing.InvestingCircuitBreaker) investing.InvestingHtmlSource {
+		return b
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = container.Provide(func(b *investing.InvestingCircuitBreaker) loading.InvestingSourceState {
+		return b
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = container.Provide(func(c *loader.Config) (*investing.SelectorProfile, error) {
+		return investing.LoadSelectorProfile(c.Source.Selectors, c.Source.SelectorFixtures)
 	})
 	if err != nil {
 		return nil, err
 	}
-	err = container.Provide(func(c *loader.Config, logger *logrus.Logger, source investing.InvestingHtmlSource) loading.InvestingDataReciver {
-		return investing.NewInvestingRepository(c, logger, source)
+	err = container.Provide(func(c *loader.Config, selectors *investing.SelectorProfile) *investing.InvestingQuarantine {
+		return investing.NewInvestingQuarantine(c.Source.QuarantineDir, selectors)
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = container.Provide(func(c *loader.Config, logger *logrus.Logger, source investing.InvestingHtmlSource, quarantine *investing.InvestingQuarantine, selectors *investing.SelectorProfile) (loading.InvestingDataReciver, error) {
+		return investing.NewInvestingRepository(c, logger, source, quarantine, selectors)
 	})
 	if err != nil {
 		return nil, err
 	}
 	err = container.Provide(func(db *sql.DB) loading.CountriesDataReciver {
-		return data.NewCountriesRepository(db)
+		r := data.NewCountriesRepository(db)
+		if dryRun != nil {
+			return data.NewCountriesDryRunSink(r, dryRun)
+		}
+		return r
 	})
 	if err != nil {
 		return nil, err
 	}
 	err = container.Provide(func(db *sql.DB) loading.EventScheduleDataReciver {
-		return data.NewEventScheduleRepository(db)
+		r := data.NewEventScheduleRepository(db)
+		if dryRun != nil {
+			return data.NewEventScheduleDryRunSink(r, dryRun)
+		}
+		return r
 	})
 	if err != nil {
 		return nil, err
 	}
 	err = container.Provide(func(db *sql.DB) loading.EventsDataReciver {
-		return data.NewEventsRepository(db)
+		r := data.NewEventsRepository(db)
+		if dryRun != nil {
+			return data.NewEventsDryRunSink(r, dryRun)
+		}
+		return r
 	})
 	if err != nil {
 		return nil, err
 	}
-	err = container.Provide(func(db *sql.DB) loading.EventsDataReciver {
-		return data.NewEventsRepository(db)
+	err = container.Provide(func(db *sql.DB) loading.HolidaysDataReciver {
+		if dryRun != nil {
+			return data.NewHolidaysDryRunSink(dryRun)
+		}
+		return data.NewHolidaysRepository(db)
 	})
 	if err != nil {
 		return nil, err
 	}
+	err = container.Provide(func(db *sql.DB) loading.LoadStatesDataReciver {
+		r := data.NewLoadStatesRepository(db)
+		if dryRun != nil {
+			return data.NewLoadStatesDryRunSink(r)
+		}
+		return r
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = container.Provide(func(db *sql.DB) loading.CoverageDataReciver {
+		return data.NewCoverageRepository(db)
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = container.Provide(func(db *sql.DB) loading.OutboxDataReciver {
+		return data.NewOutboxRepository(db)
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = container.Provide(loading.NewOutboxPublishers)
+	if err != nil {
+		return nil, err
+	}
 	err = container.Provide(loading.NewDictionariesLoaderService)
 	if err != nil {
 		return nil, err
@@ -105,6 +179,22 @@ func NewCompositionRoot() (*CompositionRoot, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = container.Provide(loading.NewRefreshScheduler)
+	if err != nil {
+		return nil, err
+	}
+	err = container.Provide(loading.NewCoverageService)
+	if err != nil {
+		return nil, err
+	}
+	err = container.Provide(loading.NewCanaryService)
+	if err != nil {
+		return nil, err
+	}
+	err = container.Provide(loading.NewOutboxRelay)
+	if err != nil {
+		return nil, err
+	}
 	err = container.Provide(NewHealtz)
 	if err != nil {
 		return nil, err
@@ -112,17 +202,48 @@ func NewCompositionRoot() (*CompositionRoot, error) {
 
 	return &CompositionRoot{
 		db:        db,
+		dryRun:    dryRun,
 		logger:    logger,
 		container: container,
 		cnf:       cnf,
 	}, nil
 }
 
+func newInvestingCircuitBreaker(c *loader.Config) (*investing.InvestingCircuitBreaker, error) {
+	source, err := newInvestingHtmlSource(c)
+	if err != nil {
+		return nil, err
+	}
+	return investing.NewInvestingCircuitBreaker(source, c.Source.BreakerThreshold, c.Source.BreakerProbe), nil
+}
+
+func newInvestingHtmlSource(c *loader.Config) (investing.InvestingHtmlSource, error) {
+	switch c.Source.Mode {
+	case "", "live":
+		return investing.NewInvestingHttpClient(c)
+	case "record":
+		client, err := investing.NewInvestingHttpClient(c)
+		if err != nil {
+			return nil, err
+		}
+		return investing.NewInvestingRecordingSource(client, c.Source.Directory), nil
+	case "replay":
+		return investing.NewInvestingReplaySource(c.Source.Directory), nil
+	}
+	return nil, fmt.Errorf("unknown investing source mode '%s'", c.Source.Mode)
+}
+
 func (r *CompositionRoot) GetContainer() *dig.Container {
 	return r.container
 }
 
 func (r *CompositionRoot) Close() {
+	if r.dryRun != nil {
+		if err := r.dryRun.Close(); err != nil {
+			r.logger.Errorf("close dry run output error: %s", err)
+		}
+		r.logger.WithField("summary", r.dryRun.Summary()).Info("dry run finished")
+	}
 	if r.db != nil {
 		r.db.Close()
 	}
@@ -142,12 +263,10 @@ func (r *CompositionRoot) InitSchedule(ctx context.Context, s *gocron.Scheduler)
 		return fmt.Errorf("history job scheduling error: %w", err)
 	}
 
-	err = r.container.Invoke(func(cnf *loader.Config) error {
-		_, err = s.Cron(r.cnf.Scheduler.RefreshExpression).
+	err = r.container.Invoke(func(cnf *loader.Config, srv *loading.RefreshScheduler) error {
+		_, err := s.Every(1).Second().
 			SingletonMode().
-			Do(func() {
-				//fmt.Printf("from refresh job\n")
-			})
+			Do(srv.Tick, ctx)
 
 		return err
 	})
@@ -155,15 +274,54 @@ func (r *CompositionRoot) InitSchedule(ctx context.Context, s *gocron.Scheduler)
 		return fmt.Errorf("refresh job scheduling error: %w", err)
 	}
 
+	err = r.container.Invoke(func(cnf *loader.Config, srv *loading.CanaryService) error {
+		if len(cnf.Scheduler.CanaryExpression) == 0 {
+			return nil
+		}
+
+		_, err := s.Cron(cnf.Scheduler.CanaryExpression).
+			SingletonMode().
+			StartImmediately().
+			Do(srv.Run, ctx)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("canary job scheduling error: %w", err)
+	}
+
+	err = r.container.Invoke(func(cnf *loader.Config, srv *loading.OutboxRelay) error {
+		// dry run has no side effects, so pending records aren't delivered
+		if !srv.IsEnabled() || cnf.Loading.DryRun || cnf.Scheduler.OutboxInterval <= 0 {
+			return nil
+		}
+
+		_, err := s.Every(cnf.Scheduler.OutboxInterval).
+			SingletonMode().
+			Do(srv.Run, ctx)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("outbox relay job scheduling error: %w", err)
+	}
+
 	return nil
 }
 
-func (r *CompositionRoot) InitHttpServer() error {
+func (r *CompositionRoot) InitHttpServer(ctx context.Context) error {
 	err := r.container.Invoke(func(h *Healtz) {
 		http.Handle("/healtz", h)
 	})
 	if err != nil {
 		return fmt.Errorf("health check handler error: %w", err)
 	}
+	http.Handle("/metrics", promhttp.Handler())
+	err = r.container.Invoke(func(cnf *loader.Config, s *loading.CoverageService) {
+		http.Handle("/admin/coverage", NewCoverageHandler(ctx, s, r.logger, cnf.Admin.Token))
+	})
+	if err != nil {
+		return fmt.Errorf("coverage handler error: %w", err)
+	}
 	return nil
 }
@@ -5,6 +5,12 @@ DROP TABLE IF EXISTS events CASCADE;
 DROP TABLE IF EXISTS event_translations CASCADE;
 DROP TABLE IF EXISTS event_schedule CASCADE;
 DROP TABLE IF EXISTS event_schedule_translations CASCADE;
+DROP TABLE IF EXISTS event_schedule_revisions CASCADE;
+DROP TABLE IF EXISTS event_schedule_versions CASCADE;
+DROP TABLE IF EXISTS event_schedule_outbox CASCADE;
+DROP TABLE IF EXISTS holidays CASCADE;
+DROP TABLE IF EXISTS holiday_translations CASCADE;
+DROP TABLE IF EXISTS history_load_states CASCADE;
 
 /* Languages and ISO 639-1 codes */
 CREATE TABLE languages
@@ -31,6 +37,9 @@ CREATE TABLE countries
 	continent_code	CHAR(2)	NOT NULL,
 	name			VARCHAR(256) NOT NULL,
 	currency		CHAR(3) NOT NULL,
+	content_hash	CHAR(64),
+	created_at		TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+	updated_at		TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
 	CONSTRAINT pk_countries PRIMARY KEY (id)
 );
 
@@ -63,6 +72,10 @@ CREATE TABLE events
 	unit			VARCHAR(16),
 	source			TEXT,
 	source_url		TEXT,
+	content_hash	CHAR(64),
+	created_at		TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+	updated_at		TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+	change_xid		BIGINT NOT NULL DEFAULT txid_current(),
 	CONSTRAINT pk_events PRIMARY KEY (id),
 	CONSTRAINT fk_events_countries FOREIGN KEY(country_id)
 		REFERENCES countries ON DELETE CASCADE
@@ -72,6 +85,10 @@ CREATE INDEX ix_events_country_id ON events (country_id);
 
 CREATE INDEX ix_events_impact_level ON events (impact_level);
 
+CREATE INDEX ix_events_updated_at ON events (updated_at);
+
+CREATE INDEX ix_events_change_xid ON events (change_xid, id);
+
 /* Calendar events main data */
 CREATE TABLE event_translations
 (
@@ -94,9 +111,29 @@ CREATE TABLE event_schedule
 	actual			DOUBLE PRECISION,
 	forecast		DOUBLE PRECISION,
 	previous		DOUBLE PRECISION,
+	actual_raw		VARCHAR(32),
+	actual_value	DOUBLE PRECISION,
+	actual_scale	VARCHAR(1),
+	actual_unit		VARCHAR(16),
+	forecast_raw	VARCHAR(32),
+	forecast_value	DOUBLE PRECISION,
+	forecast_scale	VARCHAR(1),
+	forecast_unit	VARCHAR(16),
+	previous_raw	VARCHAR(32),
+	previous_value	DOUBLE PRECISION,
+	previous_scale	VARCHAR(1),
+	previous_unit	VARCHAR(16),
+	previous_first_raw		VARCHAR(32),
+	previous_first_value	DOUBLE PRECISION,
+	previous_first_scale	VARCHAR(1),
+	previous_first_unit		VARCHAR(16),
 	done			BOOLEAN NOT NULL,
 	type			INTEGER NOT NULL,
 	event_id		INTEGER,
+	content_hash	CHAR(64),
+	created_at		TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+	updated_at		TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+	change_xid		BIGINT NOT NULL DEFAULT txid_current(),
 	CONSTRAINT pk_event_schedule PRIMARY KEY (id),
 	CONSTRAINT fk_event_schedule_events FOREIGN KEY(event_id)
 		REFERENCES events ON DELETE CASCADE
@@ -108,6 +145,10 @@ CREATE INDEX ix_event_schedule_done ON event_schedule (done);
 
 CREATE INDEX ix_event_schedule_timestamp_utc ON event_schedule (timestamp_utc DESC);
 
+CREATE INDEX ix_event_schedule_updated_at ON event_schedule (updated_at);
+
+CREATE INDEX ix_event_schedule_change_xid ON event_schedule (change_xid, id);
+
 /* Calendar schedule event translations*/
 CREATE TABLE event_schedule_translations
 (
@@ -119,4 +160,129 @@ CREATE TABLE event_schedule_translations
 		REFERENCES event_schedule ON DELETE CASCADE,
 	CONSTRAINT fk_event_schedule_translations_languages FOREIGN KEY(language_id)
 		REFERENCES languages ON DELETE CASCADE
-);
\ No newline at end of file
+);
+
+/* Revisions of previous values detected on schedule rows */
+CREATE TABLE event_schedule_revisions
+(
+	id					SERIAL NOT NULL,
+	event_schedule_id	INTEGER NOT NULL,
+	original_raw		VARCHAR(32) NOT NULL,
+	original_value		DOUBLE PRECISION NOT NULL,
+	revised_raw			VARCHAR(32) NOT NULL,
+	revised_value		DOUBLE PRECISION NOT NULL,
+	detected_at			TIMESTAMP NOT NULL,
+	CONSTRAINT pk_event_schedule_revisions PRIMARY KEY (id),
+	CONSTRAINT uq_event_schedule_revisions UNIQUE (event_schedule_id, revised_raw),
+	CONSTRAINT fk_event_schedule_revisions_event_schedule FOREIGN KEY(event_schedule_id)
+		REFERENCES event_schedule ON DELETE CASCADE
+);
+
+/* Calendar schedule versions with system time validity, written on every loader save */
+CREATE TABLE event_schedule_versions
+(
+	event_schedule_id		INTEGER NOT NULL,
+	valid_from				TIMESTAMP NOT NULL,
+	valid_to				TIMESTAMP,
+	timestamp_utc 			TIMESTAMP NOT NULL,
+	actual					DOUBLE PRECISION,
+	forecast				DOUBLE PRECISION,
+	previous				DOUBLE PRECISION,
+	actual_raw				VARCHAR(32),
+	actual_value			DOUBLE PRECISION,
+	actual_scale			VARCHAR(1),
+	actual_unit				VARCHAR(16),
+	forecast_raw			VARCHAR(32),
+	forecast_value			DOUBLE PRECISION,
+	forecast_scale			VARCHAR(1),
+	forecast_unit			VARCHAR(16),
+	previous_raw			VARCHAR(32),
+	previous_value			DOUBLE PRECISION,
+	previous_scale			VARCHAR(1),
+	previous_unit			VARCHAR(16),
+	previous_first_raw		VARCHAR(32),
+	previous_first_value	DOUBLE PRECISION,
+	previous_first_scale	VARCHAR(1),
+	previous_first_unit		VARCHAR(16),
+	done					BOOLEAN NOT NULL,
+	type					INTEGER NOT NULL,
+	event_id				INTEGER,
+	CONSTRAINT pk_event_schedule_versions PRIMARY KEY (event_schedule_id, valid_from),
+	CONSTRAINT fk_event_schedule_versions_event_schedule FOREIGN KEY(event_schedule_id)
+		REFERENCES event_schedule ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX ux_event_schedule_versions_current ON event_schedule_versions (event_schedule_id) WHERE valid_to IS NULL;
+
+CREATE INDEX ix_event_schedule_versions_valid ON event_schedule_versions (valid_from, valid_to);
+
+CREATE INDEX ix_event_schedule_versions_event_id ON event_schedule_versions (event_id);
+
+/* Outbox of event schedule changes written by loader in save transaction and delivered by relay */
+CREATE TABLE event_schedule_outbox
+(
+	id					BIGSERIAL NOT NULL,
+	event_schedule_id	INTEGER NOT NULL,
+	event_id			INTEGER,
+	before				JSONB NOT NULL,
+	after				JSONB NOT NULL,
+	created_at			TIMESTAMP NOT NULL,
+	attempts			INTEGER NOT NULL DEFAULT 0,
+	last_error			TEXT,
+	published_at		TIMESTAMP,
+	failed_at			TIMESTAMP,
+	next_attempt_at		TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+	claimed_until		TIMESTAMP,
+	CONSTRAINT pk_event_schedule_outbox PRIMARY KEY (id)
+);
+
+CREATE INDEX ix_event_schedule_outbox_pending ON event_schedule_outbox (id)
+	WHERE published_at IS NULL AND failed_at IS NULL;
+
+CREATE INDEX ix_event_schedule_outbox_pending_rows ON event_schedule_outbox (event_schedule_id, id)
+	WHERE published_at IS NULL AND failed_at IS NULL;
+
+/* Holidays and bank closures */
+CREATE TABLE holidays
+(
+	id				INTEGER NOT NULL,
+	country_id		INTEGER NOT NULL,
+	date			DATE NOT NULL,
+	CONSTRAINT pk_holidays PRIMARY KEY (id),
+	CONSTRAINT fk_holidays_countries FOREIGN KEY(country_id)
+		REFERENCES countries ON DELETE CASCADE
+);
+
+CREATE INDEX ix_holidays_country_id ON holidays (country_id);
+
+CREATE INDEX ix_holidays_date ON holidays (date DESC);
+
+/* Holiday title translations */
+CREATE TABLE holiday_translations
+(
+	holiday_id		INTEGER NOT NULL,
+	language_id		INTEGER NOT NULL,
+	title			VARCHAR(1024) NOT NULL,
+	CONSTRAINT pk_holiday_translations PRIMARY KEY (holiday_id, language_id),
+	CONSTRAINT fk_holiday_translations_holidays FOREIGN KEY(holiday_id)
+		REFERENCES holidays ON DELETE CASCADE,
+	CONSTRAINT fk_holiday_translations_languages FOREIGN KEY(language_id)
+		REFERENCES languages ON DELETE CASCADE
+);
+
+/* History loading checkpoints per calendar day and language */
+CREATE TABLE history_load_states
+(
+	date			DATE NOT NULL,
+	language_id		INTEGER NOT NULL,
+	status			VARCHAR(16) NOT NULL,
+	row_count		INTEGER NOT NULL,
+	attempts		INTEGER NOT NULL,
+	last_error		TEXT,
+	updated_at		TIMESTAMP NOT NULL,
+	CONSTRAINT pk_history_load_states PRIMARY KEY (date, language_id),
+	CONSTRAINT fk_history_load_states_languages FOREIGN KEY(language_id)
+		REFERENCES languages ON DELETE CASCADE
+);
+
+CREATE INDEX ix_history_load_states_status ON history_load_states (status);
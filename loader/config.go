@@ -15,18 +15,64 @@ type Config struct {
 		ConnectionString string `mapstructure:"DB_CONSTR"`
 	} `mapstructure:",squash"`
 	Loading struct {
-		DefaultLanguageId int       `mapstructure:"LOADING_BATCHSIZE"`
-		RetryCount        int       `mapstructure:"LOADING_DEFAULTLANG"`
-		BatchSize         int       `mapstructure:"LOADING_RETRYCOUNT"`
-		FromTime          time.Time `mapstructure:"LOADING_FROMTIME"`
-		ToDays            int       `mapstructure:"LOADING_TODAYS"`
+		DefaultLanguageId int           `mapstructure:"LOADING_DEFAULTLANG"`
+		RetryCount        int           `mapstructure:"LOADING_RETRYCOUNT"`
+		BatchSize         int           `mapstructure:"LOADING_BATCHSIZE"`
+		FromTime          time.Time     `mapstructure:"LOADING_FROMTIME"`
+		ToDays            int           `mapstructure:"LOADING_TODAYS"`
+		RefreshWindow     time.Duration `mapstructure:"LOADING_REFRESHWINDOW"`
+		DryRun            bool          `mapstructure:"LOADING_DRYRUN"`
+		DryRunOutput      string        `mapstructure:"LOADING_DRYRUNOUTPUT"`
+	} `mapstructure:",squash"`
+	Source struct {
+		Mode             string        `mapstructure:"SOURCE_MODE"`
+		Directory        string        `mapstructure:"SOURCE_DIR"`
+		BaseUrl          string        `mapstructure:"SOURCE_BASEURL"`
+		RateLimit        float64       `mapstructure:"SOURCE_RATELIMIT"`
+		RateBurst        int           `mapstructure:"SOURCE_RATEBURST"`
+		MinBackoff       time.Duration `mapstructure:"SOURCE_MINBACKOFF"`
+		MaxBackoff       time.Duration `mapstructure:"SOURCE_MAXBACKOFF"`
+		BreakerThreshold int           `mapstructure:"SOURCE_BREAKERTHRESHOLD"`
+		BreakerProbe     time.Duration `mapstructure:"SOURCE_BREAKERPROBE"`
+		Timeout          time.Duration `mapstructure:"SOURCE_TIMEOUT"`
+		Proxies          string        `mapstructure:"SOURCE_PROXIES"`
+		ProxyCooldown    time.Duration `mapstructure:"SOURCE_PROXYCOOLDOWN"`
+		UserAgents       string        `mapstructure:"SOURCE_USERAGENTS"`
+		CookieFile       string        `mapstructure:"SOURCE_COOKIEFILE"`
+		MaxResponseSize  int64         `mapstructure:"SOURCE_MAXRESPONSESIZE"`
+		QuarantineDir    string        `mapstructure:"SOURCE_QUARANTINEDIR"`
+		ParseMode        string        `mapstructure:"SOURCE_PARSEMODE"`
+		Selectors        string        `mapstructure:"SOURCE_SELECTORS"`
+		SelectorFixtures string        `mapstructure:"SOURCE_SELECTORFIXTURES"`
 	} `mapstructure:",squash"`
 	Logging struct {
 		Level log.Level `mapstructure:"LOG_LEVEL"`
 	} `mapstructure:",squash"`
 	Scheduler struct {
-		HistoryExpression string `mapstructure:"SCHEDULER_HISTEXPR"`
-		RefreshExpression string `mapstructure:"SCHEDULER_REFREXPR"`
+		HistoryExpression string          `mapstructure:"SCHEDULER_HISTEXPR"`
+		RefreshIdle       time.Duration   `mapstructure:"SCHEDULER_REFRIDLE"`
+		RefreshIntervals  []time.Duration `mapstructure:"SCHEDULER_REFRINTERVALS"`
+		CanaryExpression  string          `mapstructure:"SCHEDULER_CANARYEXPR"`
+		OutboxInterval    time.Duration   `mapstructure:"SCHEDULER_OUTBOXINTERVAL"`
+	} `mapstructure:",squash"`
+	Canary struct {
+		Day       time.Time `mapstructure:"CANARY_DAY"`
+		EventId   int       `mapstructure:"CANARY_EVENTID"`
+		Baseline  string    `mapstructure:"CANARY_BASELINE"`
+		Tolerance float64   `mapstructure:"CANARY_TOLERANCE"`
+	} `mapstructure:",squash"`
+	Outbox struct {
+		Publishers     []string      `mapstructure:"OUTBOX_PUBLISHERS"`
+		WebhookUrl     string        `mapstructure:"OUTBOX_WEBHOOKURL"`
+		WebhookTimeout time.Duration `mapstructure:"OUTBOX_WEBHOOKTIMEOUT"`
+		BatchSize      int           `mapstructure:"OUTBOX_BATCHSIZE"`
+		MaxAttempts    int           `mapstructure:"OUTBOX_MAXATTEMPTS"`
+		MinBackoff     time.Duration `mapstructure:"OUTBOX_MINBACKOFF"`
+		MaxBackoff     time.Duration `mapstructure:"OUTBOX_MAXBACKOFF"`
+		Lease          time.Duration `mapstructure:"OUTBOX_LEASE"`
+	} `mapstructure:",squash"`
+	Admin struct {
+		Token string `mapstructure:"ADMIN_TOKEN"`
 	} `mapstructure:",squash"`
 }
 
@@ -2,10 +2,15 @@ package data
 
 import (
 	"database/sql"
+	"sort"
 
 	sq "github.com/Masterminds/squirrel"
 )
 
+// saveBatchSize limits rows of one multi-row statement, so statement parameters count stays
+// under postgres limit of 65535 parameters.
+const saveBatchSize = 500
+
 type baseRepository struct {
 	db *sql.DB
 }
@@ -13,3 +18,12 @@ type baseRepository struct {
 func (r *baseRepository) initQueryBuilder() sq.StatementBuilderType {
 	return sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 }
+
+func sortedColumns(values map[string]interface{}) []string {
+	columns := make([]string, 0, len(values))
+	for column := range values {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return columns
+}
@@ -0,0 +1,128 @@
+package data
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/denis-gudim/economic-calendar/loader/metrics"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// WriteStats are counts of rows inserted, updated and skipped as unchanged by repository writes.
+type WriteStats struct {
+	Inserted  int `json:"inserted"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+}
+
+func (s *WriteStats) Add(other WriteStats) {
+	s.Inserted += other.Inserted
+	s.Updated += other.Updated
+	s.Unchanged += other.Unchanged
+}
+
+func (s WriteStats) String() string {
+	return fmt.Sprintf("inserted = %d, updated = %d, unchanged = %d", s.Inserted, s.Updated, s.Unchanged)
+}
+
+// report exports stats of committed write into metrics.
+func (s WriteStats) report(repository string) {
+	metrics.RowsUpserted.WithLabelValues(repository, "insert").Add(float64(s.Inserted))
+	metrics.RowsUpserted.WithLabelValues(repository, "update").Add(float64(s.Updated))
+	metrics.RowsUnchanged.WithLabelValues(repository).Add(float64(s.Unchanged))
+}
+
+// contentHash returns sha256 of value JSON, maps are marshaled with sorted keys, so hash
+// doesn't depend on translations order.
+func contentHash(value interface{}) (string, error) {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("marshal content hash value error: %w", err)
+	}
+
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// rowChange is result of comparison of row content hash with stored one.
+type rowChange int
+
+const (
+	rowInserted rowChange = iota
+	rowUpdated
+	rowUnchanged
+)
+
+// storedHashes holds content hashes of stored rows by id, empty hash means row is stored
+// without hash.
+type storedHashes map[int]string
+
+// change compares row hash with stored one and counts result into stats.
+func (h storedHashes) change(id int, hash string, stats *WriteStats) rowChange {
+	stored, ok := h[id]
+
+	switch {
+	case !ok:
+		stats.Inserted++
+		return rowInserted
+	case stored == hash:
+		stats.Unchanged++
+		return rowUnchanged
+	default:
+		stats.Updated++
+		return rowUpdated
+	}
+}
+
+func (r *baseRepository) getContentHashes(ctx context.Context, runner sq.BaseRunner, table string, ids []int) (storedHashes, error) {
+	return r.queryContentHashes(ctx, runner, table, ids, "")
+}
+
+// lockContentHashes locks stored rows till the end of transaction and returns their content
+// hashes, so rows can't be changed between comparison and write. Rows are locked in id
+// order to avoid deadlocks with concurrent writes.
+func (r *baseRepository) lockContentHashes(ctx context.Context, tx *sql.Tx, table string, ids []int) (storedHashes, error) {
+	return r.queryContentHashes(ctx, tx, table, ids, "FOR UPDATE")
+}
+
+func (r *baseRepository) queryContentHashes(ctx context.Context, runner sq.BaseRunner, table string, ids []int, suffix string) (storedHashes, error) {
+	hashes := make(storedHashes, len(ids))
+
+	if len(ids) == 0 {
+		return hashes, nil
+	}
+
+	rows, err := r.initQueryBuilder().
+		Select("id", "content_hash").
+		From(table).
+		Where(sq.Eq{"id": ids}).
+		OrderBy("id").
+		Suffix(suffix).
+		RunWith(runner).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("execute select content hashes query error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id   int
+			hash sql.NullString
+		)
+
+		if err = rows.Scan(&id, &hash); err != nil {
+			return nil, fmt.Errorf("scan content hash row error: %w", err)
+		}
+
+		hashes[id] = hash.String
+	}
+
+	return hashes, rows.Err()
+}
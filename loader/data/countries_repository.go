@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 )
@@ -21,7 +22,7 @@ func NewCountriesRepository(db *sql.DB) *CountriesRepository {
 func (r *CountriesRepository) GetAll(ctx context.Context) (countries []Country, err error) {
 	countries = make([]Country, 0, 100)
 	rows, err := r.initQueryBuilder().
-		Select("c.*, ct.language_id, ct.title").
+		Select("c.id, c.code, c.continent_code, c.name, c.currency, ct.language_id, ct.title").
 		From("countries c").
 		LeftJoin("country_translations ct ON c.id = ct.country_id").
 		OrderBy("c.id").
@@ -67,10 +68,20 @@ func (r *CountriesRepository) GetAll(ctx context.Context) (countries []Country,
 	return
 }
 
-func (r *CountriesRepository) Save(ctx context.Context, c Country) error {
+// Save upserts country with translations, country is skipped when its content hash matches
+// stored one, so updated_at is moved only by real changes. Stored hash is read under row
+// lock in the same transaction as write.
+func (r *CountriesRepository) Save(ctx context.Context, c Country) (WriteStats, error) {
+	stats := WriteStats{}
+
+	hash, err := contentHash(c)
+	if err != nil {
+		return stats, err
+	}
+
 	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
 	if err != nil {
-		return fmt.Errorf("create db transaction error: %w", err)
+		return WriteStats{}, fmt.Errorf("create db transaction error: %w", err)
 	}
 	defer func() {
 		if tx != nil && err != nil {
@@ -80,21 +91,38 @@ func (r *CountriesRepository) Save(ctx context.Context, c Country) error {
 		}
 	}()
 
+	hashes, err := r.lockContentHashes(ctx, tx, "countries", []int{c.Id})
+	if err != nil {
+		return WriteStats{}, err
+	}
+
+	if hashes.change(c.Id, hash, &stats) == rowUnchanged {
+		if err = tx.Commit(); err != nil {
+			return WriteStats{}, fmt.Errorf("commit transaction error: %w", err)
+		}
+		stats.report("countries")
+		return stats, nil
+	}
+
+	now := time.Now().UTC()
+
 	upsertQuery := r.initQueryBuilder().
 		Insert("countries").
-		Columns("id", "code", "continent_code", "currency", "name").
-		Values(c.Id, c.Code, c.ContinentCode, c.Currency, c.Name).
+		Columns("id", "code", "continent_code", "currency", "name", "content_hash", "created_at", "updated_at").
+		Values(c.Id, c.Code, c.ContinentCode, c.Currency, c.Name, hash, now, now).
 		Suffix("ON CONFLICT (id) DO").
 		SuffixExpr(
 			sq.Update(" ").
 				Set("code", c.Code).
 				Set("continent_code", c.ContinentCode).
 				Set("currency", c.Currency).
-				Set("name", c.Name))
+				Set("name", c.Name).
+				Set("content_hash", hash).
+				Set("updated_at", now))
 
 	_, err = upsertQuery.RunWith(tx).ExecContext(ctx)
 	if err != nil {
-		return fmt.Errorf("execute upsert query error: %w", err)
+		return WriteStats{}, fmt.Errorf("execute upsert query error: %w", err)
 	}
 
 	deleteQuery := r.initQueryBuilder().
@@ -103,7 +131,7 @@ func (r *CountriesRepository) Save(ctx context.Context, c Country) error {
 
 	_, err = deleteQuery.RunWith(tx).ExecContext(ctx)
 	if err != nil {
-		return fmt.Errorf("delete translations query error: %w", err)
+		return WriteStats{}, fmt.Errorf("delete translations query error: %w", err)
 	}
 
 	for langId, title := range c.NameTranslations {
@@ -116,13 +144,15 @@ func (r *CountriesRepository) Save(ctx context.Context, c Country) error {
 		_, err = insertQuery.RunWith(tx).ExecContext(ctx)
 
 		if err != nil {
-			return fmt.Errorf("execute insert translation query error: %w", err)
+			return WriteStats{}, fmt.Errorf("execute insert translation query error: %w", err)
 		}
 	}
 
 	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("commit transaction: %w", err)
+		return WriteStats{}, fmt.Errorf("commit transaction: %w", err)
 	}
 
-	return nil
+	stats.report("countries")
+
+	return stats, nil
 }
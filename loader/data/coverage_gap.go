@@ -0,0 +1,10 @@
+package data
+
+import "time"
+
+// TitlesGap is number of schedule rows of the day which have no title in the language.
+type TitlesGap struct {
+	Date       time.Time
+	LanguageId int
+	Count      int
+}
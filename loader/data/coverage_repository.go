@@ -0,0 +1,136 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type CoverageRepository struct {
+	baseRepository
+}
+
+func NewCoverageRepository(db *sql.DB) *CoverageRepository {
+	r := CoverageRepository{}
+	r.db = db
+	return &r
+}
+
+// GetEmptyDays returns days between dates (inclusive) which have no event schedule rows.
+// Days loaded from source without rows (weekends and market holidays) aren't gaps.
+func (r *CoverageRepository) GetEmptyDays(ctx context.Context, from, to time.Time) (days []time.Time, err error) {
+	days = make([]time.Time, 0, 16)
+
+	series := sq.Select().
+		Column("generate_series(?::date, ?::date, interval '1 day')::date AS day", from, to)
+
+	rows, err := r.initQueryBuilder().
+		Select("d.day").
+		FromSelect(series, "d").
+		Where("NOT EXISTS (SELECT 1 FROM event_schedule es WHERE es.timestamp_utc >= d.day AND es.timestamp_utc < d.day + interval '1 day')").
+		Where("NOT EXISTS (SELECT 1 FROM history_load_states ls WHERE ls.date = d.day AND ls.status = ? AND ls.row_count = 0)", LoadStatusDone).
+		OrderBy("d.day DESC").
+		RunWith(r.db).
+		QueryContext(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("get empty days: execute select query error: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var day time.Time
+
+		if err = rows.Scan(&day); err != nil {
+			return nil, fmt.Errorf("get empty days: scan row error: %w", err)
+		}
+
+		days = append(days, day)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("get empty days: read rows error: %w", err)
+	}
+
+	return
+}
+
+// GetTitlesGaps returns days between dates (inclusive) where schedule rows miss titles in any of languages.
+func (r *CoverageRepository) GetTitlesGaps(ctx context.Context, from, to time.Time, languageIds []int) (gaps []TitlesGap, err error) {
+	gaps = make([]TitlesGap, 0, 16)
+
+	rows, err := r.initQueryBuilder().
+		Select("es.timestamp_utc::date AS day", "l.id", "COUNT(*)").
+		From("event_schedule es").
+		JoinClause("CROSS JOIN languages l").
+		LeftJoin("event_schedule_translations est ON est.event_schedule_id = es.id AND est.language_id = l.id").
+		Where(sq.GtOrEq{"es.timestamp_utc": from}).
+		Where(sq.Lt{"es.timestamp_utc": to.AddDate(0, 0, 1)}).
+		Where(sq.Eq{"l.id": languageIds}).
+		Where("est.event_schedule_id IS NULL").
+		GroupBy("day", "l.id").
+		OrderBy("day DESC", "l.id").
+		RunWith(r.db).
+		QueryContext(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("get titles gaps: execute select query error: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		gap := TitlesGap{}
+
+		if err = rows.Scan(&gap.Date, &gap.LanguageId, &gap.Count); err != nil {
+			return nil, fmt.Errorf("get titles gaps: scan row error: %w", err)
+		}
+
+		gaps = append(gaps, gap)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("get titles gaps: read rows error: %w", err)
+	}
+
+	return
+}
+
+// GetUntranslatedEvents returns identifiers of events which have no translations at all.
+func (r *CoverageRepository) GetUntranslatedEvents(ctx context.Context) (ids []int, err error) {
+	ids = make([]int, 0, 16)
+
+	rows, err := r.initQueryBuilder().
+		Select("e.id").
+		From("events e").
+		Where("NOT EXISTS (SELECT 1 FROM event_translations et WHERE et.event_id = e.id)").
+		OrderBy("e.id").
+		RunWith(r.db).
+		QueryContext(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("get untranslated events: execute select query error: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+
+		if err = rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("get untranslated events: scan row error: %w", err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("get untranslated events: read rows error: %w", err)
+	}
+
+	return
+}
@@ -0,0 +1,307 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+var dryRunActions = map[rowChange]string{
+	rowInserted: "insert",
+	rowUpdated:  "update",
+}
+
+type dryRunRecord struct {
+	Table  string      `json:"table"`
+	Action string      `json:"action"`
+	Data   interface{} `json:"data"`
+}
+
+// DryRunWriter writes rows which would be saved into database as JSON Lines, every line
+// is marked as insert or update compared with rows currently stored in database. Rows which
+// content hash matches stored one are only counted as unchanged.
+type DryRunWriter struct {
+	baseRepository
+	mu      sync.Mutex
+	writer  io.Writer
+	closer  io.Closer
+	summary map[string]*WriteStats
+}
+
+// NewDryRunWriter creates writer into file, stdout is used when file name is empty.
+func NewDryRunWriter(db *sql.DB, fileName string) (*DryRunWriter, error) {
+	w := DryRunWriter{
+		writer:  os.Stdout,
+		summary: make(map[string]*WriteStats),
+	}
+	w.db = db
+
+	if len(fileName) > 0 {
+		file, err := os.Create(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("create dry run output file error: %w", err)
+		}
+		w.writer = file
+		w.closer = file
+	}
+
+	return &w, nil
+}
+
+// Summary returns counts of written rows by table.
+func (w *DryRunWriter) Summary() map[string]WriteStats {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	summary := make(map[string]WriteStats, len(w.summary))
+	for table, s := range w.summary {
+		summary[table] = *s
+	}
+
+	return summary
+}
+
+// Close writes summary as the last line and closes output file.
+func (w *DryRunWriter) Close() error {
+	err := w.encode(struct {
+		Summary map[string]WriteStats `json:"summary"`
+	}{
+		Summary: w.Summary(),
+	})
+
+	if w.closer != nil {
+		if cerr := w.closer.Close(); err == nil {
+			err = cerr
+		}
+	}
+
+	return err
+}
+
+func (w *DryRunWriter) write(ctx context.Context, table string, id int, value interface{}) (WriteStats, error) {
+	hash, err := contentHash(value)
+	if err != nil {
+		return WriteStats{}, fmt.Errorf("dry run %s row %d: %w", table, id, err)
+	}
+
+	hashes, err := w.getContentHashes(ctx, w.db, table, []int{id})
+	if err != nil {
+		return WriteStats{}, fmt.Errorf("dry run %s row %d: %w", table, id, err)
+	}
+
+	return w.writeChange(table, value, hashes.change(id, hash, &WriteStats{}))
+}
+
+// writeExists writes row of table without content hash column, so row is either inserted
+// or updated.
+func (w *DryRunWriter) writeExists(ctx context.Context, table string, id int, value interface{}) (WriteStats, error) {
+	exists, err := w.exists(ctx, table, id)
+	if err != nil {
+		return WriteStats{}, fmt.Errorf("dry run %s row %d: %w", table, id, err)
+	}
+
+	change := rowInserted
+	if exists {
+		change = rowUpdated
+	}
+
+	return w.writeChange(table, value, change)
+}
+
+func (w *DryRunWriter) writeChange(table string, value interface{}, change rowChange) (WriteStats, error) {
+	stats := WriteStats{}
+
+	switch change {
+	case rowInserted:
+		stats.Inserted++
+	case rowUpdated:
+		stats.Updated++
+	default:
+		stats.Unchanged++
+	}
+
+	if action, ok := dryRunActions[change]; ok {
+		if err := w.encode(dryRunRecord{Table: table, Action: action, Data: value}); err != nil {
+			return WriteStats{}, fmt.Errorf("dry run %s row: write error: %w", table, err)
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	summary, ok := w.summary[table]
+	if !ok {
+		summary = &WriteStats{}
+		w.summary[table] = summary
+	}
+
+	summary.Add(stats)
+
+	return stats, nil
+}
+
+func (w *DryRunWriter) encode(value interface{}) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return json.NewEncoder(w.writer).Encode(value)
+}
+
+func (w *DryRunWriter) exists(ctx context.Context, table string, id int) (exists bool, err error) {
+	query, args, err := w.initQueryBuilder().
+		Select("1").
+		From(table).
+		Where(sq.Eq{"id": id}).
+		Prefix("SELECT EXISTS (").
+		Suffix(")").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("build exists query error: %w", err)
+	}
+
+	if err = w.db.QueryRowContext(ctx, query, args...).Scan(&exists); err != nil {
+		return false, fmt.Errorf("execute exists query error: %w", err)
+	}
+
+	return
+}
+
+// Dry run sinks hold repositories in named fields and forward only read methods to them, so
+// any write method added to repository doesn't reach database through sink.
+
+// EventScheduleDryRunSink reads events schedule from database and writes saved rows into
+// dry run writer instead of database.
+type EventScheduleDryRunSink struct {
+	repository *EventScheduleRepository
+	writer     *DryRunWriter
+}
+
+func NewEventScheduleDryRunSink(r *EventScheduleRepository, w *DryRunWriter) *EventScheduleDryRunSink {
+	return &EventScheduleDryRunSink{repository: r, writer: w}
+}
+
+func (s *EventScheduleDryRunSink) GetFirst(ctx context.Context, done bool) (*EventSchedule, error) {
+	return s.repository.GetFirst(ctx, done)
+}
+
+func (s *EventScheduleDryRunSink) GetByDates(ctx context.Context, from, to time.Time) ([]EventSchedule, error) {
+	return s.repository.GetByDates(ctx, from, to)
+}
+
+func (s *EventScheduleDryRunSink) GetReleases(ctx context.Context, from, to time.Time) ([]EventRelease, error) {
+	return s.repository.GetReleases(ctx, from, to)
+}
+
+func (s *EventScheduleDryRunSink) GetNewestDoneTimeStamp(ctx context.Context) (time.Time, error) {
+	return s.repository.GetNewestDoneTimeStamp(ctx)
+}
+
+func (s *EventScheduleDryRunSink) Save(ctx context.Context, es EventSchedule) (WriteStats, error) {
+	return s.SaveBatch(ctx, []EventSchedule{es})
+}
+
+// SaveBatch writes rows with stored titles merged in as repository does, so rows loaded for
+// part of languages aren't reported as updated.
+func (s *EventScheduleDryRunSink) SaveBatch(ctx context.Context, rows []EventSchedule) (WriteStats, error) {
+	stats := WriteStats{}
+
+	rows, err := s.repository.mergeStoredTranslations(ctx, s.repository.db, uniqueEventSchedules(rows))
+	if err != nil {
+		return stats, fmt.Errorf("dry run event_schedule rows: %w", err)
+	}
+
+	for _, es := range rows {
+		rowStats, err := s.writer.write(ctx, "event_schedule", es.Id, es)
+		if err != nil {
+			return stats, err
+		}
+		stats.Add(rowStats)
+	}
+
+	return stats, nil
+}
+
+type EventsDryRunSink struct {
+	repository *EventsRepository
+	writer     *DryRunWriter
+}
+
+func NewEventsDryRunSink(r *EventsRepository, w *DryRunWriter) *EventsDryRunSink {
+	return &EventsDryRunSink{repository: r, writer: w}
+}
+
+func (s *EventsDryRunSink) GetById(ctx context.Context, id int) (*Event, error) {
+	return s.repository.GetById(ctx, id)
+}
+
+func (s *EventsDryRunSink) Save(ctx context.Context, e Event) (WriteStats, error) {
+	return s.writer.write(ctx, "events", e.Id, e)
+}
+
+type CountriesDryRunSink struct {
+	repository *CountriesRepository
+	writer     *DryRunWriter
+}
+
+func NewCountriesDryRunSink(r *CountriesRepository, w *DryRunWriter) *CountriesDryRunSink {
+	return &CountriesDryRunSink{repository: r, writer: w}
+}
+
+func (s *CountriesDryRunSink) GetAll(ctx context.Context) ([]Country, error) {
+	return s.repository.GetAll(ctx)
+}
+
+func (s *CountriesDryRunSink) Save(ctx context.Context, c Country) (WriteStats, error) {
+	return s.writer.write(ctx, "countries", c.Id, c)
+}
+
+type HolidaysDryRunSink struct {
+	writer *DryRunWriter
+}
+
+func NewHolidaysDryRunSink(w *DryRunWriter) *HolidaysDryRunSink {
+	return &HolidaysDryRunSink{writer: w}
+}
+
+func (s *HolidaysDryRunSink) Save(ctx context.Context, h Holiday) error {
+	_, err := s.writer.writeExists(ctx, "holidays", h.Id, h)
+	return err
+}
+
+func (s *HolidaysDryRunSink) SaveBatch(ctx context.Context, holidays []Holiday) error {
+	for _, h := range holidays {
+		if err := s.Save(ctx, h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadStatesDryRunSink reads load states from database and drops saved ones, so dry run
+// doesn't mark loaded days.
+type LoadStatesDryRunSink struct {
+	repository *LoadStatesRepository
+}
+
+func NewLoadStatesDryRunSink(r *LoadStatesRepository) *LoadStatesDryRunSink {
+	return &LoadStatesDryRunSink{repository: r}
+}
+
+func (s *LoadStatesDryRunSink) GetByDates(ctx context.Context, from, to time.Time) ([]LoadState, error) {
+	return s.repository.GetByDates(ctx, from, to)
+}
+
+func (s *LoadStatesDryRunSink) Save(ctx context.Context, state LoadState) error {
+	return nil
+}
+
+func (s *LoadStatesDryRunSink) Seed(ctx context.Context, before time.Time) (int, error) {
+	return 0, nil
+}
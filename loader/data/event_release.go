@@ -0,0 +1,8 @@
+package data
+
+import "time"
+
+type EventRelease struct {
+	TimeStamp   time.Time
+	ImpactLevel int
+}
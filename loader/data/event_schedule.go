@@ -3,13 +3,19 @@ package data
 import "time"
 
 type EventSchedule struct {
-	Id                int
-	TimeStamp         time.Time
-	Actual            *float64
-	Forecast          *float64
-	Previous          *float64
-	IsDone            bool
-	Type              int
-	EventId           int
-	TitleTranslations Translations
+	Id            int
+	TimeStamp     time.Time
+	Actual        *float64
+	Forecast      *float64
+	Previous      *float64
+	ActualValue   *IndexValue
+	ForecastValue *IndexValue
+	PreviousValue *IndexValue
+	// PreviousRevisedFrom is originally reported previous value, set when source
+	// marks the previous figure as revised.
+	PreviousRevisedFrom *IndexValue
+	IsDone              bool
+	Type                int
+	EventId             int
+	TitleTranslations   Translations
 }
@@ -4,11 +4,22 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
 )
 
+// eventScheduleVersionColumns are event_schedule columns copied into event_schedule_versions
+var eventScheduleVersionColumns = []string{
+	"timestamp_utc", "actual", "forecast", "previous", "done", "type", "event_id",
+	"actual_raw", "actual_value", "actual_scale", "actual_unit",
+	"forecast_raw", "forecast_value", "forecast_scale", "forecast_unit",
+	"previous_raw", "previous_value", "previous_scale", "previous_unit",
+	"previous_first_raw", "previous_first_value", "previous_first_scale", "previous_first_unit",
+}
+
 type EventScheduleRepository struct {
 	baseRepository
 }
@@ -59,10 +70,78 @@ func (r *EventScheduleRepository) GetByDates(ctx context.Context, from, to time.
 	return r.getWithFilter(ctx, filter, fmtError)
 }
 
-func (r *EventScheduleRepository) Save(ctx context.Context, es EventSchedule) error {
+// GetReleases returns times and impact levels of schedule rows between dates which aren't
+// done yet, so releases already captured don't keep refresh frequent.
+func (r *EventScheduleRepository) GetReleases(ctx context.Context, from, to time.Time) (releases []EventRelease, err error) {
+	fmtError := func(text string, err error) error {
+		return fmt.Errorf("get releases ( from: %s, to: %s ): %s: %w", from, to, text, err)
+	}
+
+	releases = make([]EventRelease, 0, 64)
+
+	rows, err := r.initQueryBuilder().
+		Select("es.timestamp_utc, e.impact_level").
+		From("event_schedule es").
+		Join("events e ON e.id = es.event_id").
+		Where(sq.And{
+			sq.GtOrEq{"es.timestamp_utc": from},
+			sq.Lt{"es.timestamp_utc": to},
+			sq.Eq{"es.done": false},
+		}).
+		OrderBy("es.timestamp_utc").
+		RunWith(r.db).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmtError("execute select query", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		release := EventRelease{}
+		if err = rows.Scan(&release.TimeStamp, &release.ImpactLevel); err != nil {
+			return nil, fmtError("scan row", err)
+		}
+		releases = append(releases, release)
+	}
+
+	return
+}
+
+// GetNewestDoneTimeStamp returns timestamp of the newest stored schedule row which is done,
+// zero time when there are no such rows. Schedule contains rows planned ahead, so only done
+// rows tell how fresh loaded data is.
+func (r *EventScheduleRepository) GetNewestDoneTimeStamp(ctx context.Context) (time.Time, error) {
+	var newest sql.NullTime
+
+	err := r.initQueryBuilder().
+		Select("MAX(timestamp_utc)").
+		From("event_schedule").
+		Where(sq.Eq{"done": true}).
+		RunWith(r.db).
+		QueryRowContext(ctx).
+		Scan(&newest)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("get newest done events schedule timestamp: %w", err)
+	}
+
+	return newest.Time, nil
+}
+
+// Save upserts schedule row, its revision, version and translations, row is skipped when
+// its content hash matches stored one.
+func (r *EventScheduleRepository) Save(ctx context.Context, es EventSchedule) (WriteStats, error) {
+	return r.SaveBatch(ctx, []EventSchedule{es})
+}
+
+// SaveBatch upserts schedule rows, their revisions, versions and translations in one
+// transaction using multi-row statements of up to saveBatchSize rows. Rows with the same
+// id are merged, the last one wins. Rows which content hash matches stored one are skipped,
+// so updated_at is moved only by real changes. Changes of timestamp, actual, forecast,
+// previous and done values of stored rows are written into outbox in the same transaction.
+func (r *EventScheduleRepository) SaveBatch(ctx context.Context, rows []EventSchedule) (stats WriteStats, err error) {
 	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
 	if err != nil {
-		return fmt.Errorf("create db transaction error: %w", err)
+		return WriteStats{}, fmt.Errorf("save events schedule batch: create db transaction error: %w", err)
 	}
 	defer func() {
 		if tx != nil && err != nil {
@@ -72,47 +151,326 @@ func (r *EventScheduleRepository) Save(ctx context.Context, es EventSchedule) er
 		}
 	}()
 
+	rows = uniqueEventSchedules(rows)
+	now := time.Now().UTC()
+
+	for start := 0; start < len(rows); start += saveBatchSize {
+		end := start + saveBatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		changed, hashes, err := r.getChangedRows(ctx, tx, rows[start:end], &stats)
+		if err != nil {
+			return WriteStats{}, fmt.Errorf("save events schedule batch: %w", err)
+		}
+
+		if len(changed) == 0 {
+			continue
+		}
+
+		if err = r.saveBatchChunk(ctx, tx, changed, hashes, now); err != nil {
+			return WriteStats{}, fmt.Errorf("save events schedule batch: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return WriteStats{}, fmt.Errorf("save events schedule batch: commit transaction error: %w", err)
+	}
+
+	stats.report("event_schedule")
+
+	return stats, nil
+}
+
+// getChangedRows locks stored rows till the end of transaction and returns rows which content
+// differs from stored one together with content hashes of returned rows by id. Stored titles
+// are merged into rows before hashing, so rows loaded for part of languages are compared with
+// the content they are going to have after save.
+func (r *EventScheduleRepository) getChangedRows(ctx context.Context, tx *sql.Tx, rows []EventSchedule, stats *WriteStats) ([]EventSchedule, map[int]string, error) {
+	ids := make([]int, 0, len(rows))
+	for _, es := range rows {
+		ids = append(ids, es.Id)
+	}
+
+	stored, err := r.lockContentHashes(ctx, tx, "event_schedule", ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err = r.mergeStoredTranslations(ctx, tx, rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changed := make([]EventSchedule, 0, len(rows))
+	hashes := make(map[int]string, len(rows))
+
+	for _, es := range rows {
+		hash, err := contentHash(es)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if stored.change(es.Id, hash, stats) != rowUnchanged {
+			changed = append(changed, es)
+			hashes[es.Id] = hash
+		}
+	}
+
+	return changed, hashes, nil
+}
+
+// mergeStoredTranslations returns copies of rows with stored titles added for languages
+// missing in rows, titles of rows take precedence.
+func (r *EventScheduleRepository) mergeStoredTranslations(ctx context.Context, runner sq.BaseRunner, rows []EventSchedule) ([]EventSchedule, error) {
+	ids := make([]int, 0, len(rows))
+	for _, es := range rows {
+		ids = append(ids, es.Id)
+	}
+
+	translations, err := r.getTranslations(ctx, runner, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := make([]EventSchedule, len(rows))
+
+	for i, es := range rows {
+		stored, ok := translations[es.Id]
+
+		if ok {
+			titles := make(Translations, len(stored)+len(es.TitleTranslations))
+			for langId, title := range stored {
+				titles[langId] = title
+			}
+			for langId, title := range es.TitleTranslations {
+				titles[langId] = title
+			}
+			es.TitleTranslations = titles
+		}
+
+		merged[i] = es
+	}
+
+	return merged, nil
+}
+
+func (r *EventScheduleRepository) getTranslations(ctx context.Context, runner sq.BaseRunner, ids []int) (map[int]Translations, error) {
+	translations := make(map[int]Translations, len(ids))
+
+	if len(ids) == 0 {
+		return translations, nil
+	}
+
+	rows, err := r.initQueryBuilder().
+		Select("event_schedule_id", "language_id", "title").
+		From("event_schedule_translations").
+		Where(sq.Eq{"event_schedule_id": ids}).
+		RunWith(runner).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("execute select translations query error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id, langId int
+			title      string
+		)
+
+		if err = rows.Scan(&id, &langId, &title); err != nil {
+			return nil, fmt.Errorf("scan translation row error: %w", err)
+		}
+
+		if _, ok := translations[id]; !ok {
+			translations[id] = make(Translations)
+		}
+
+		translations[id][langId] = title
+	}
+
+	return translations, rows.Err()
+}
+
+func (r *EventScheduleRepository) saveBatchChunk(ctx context.Context, tx *sql.Tx, rows []EventSchedule, hashes map[int]string, now time.Time) (err error) {
+	columns := sortedColumns(eventScheduleInsertValues(rows[0], hashes[rows[0].Id], now))
+	update := make([]string, 0, len(columns))
+
+	for _, column := range columns {
+		switch {
+		case column == "id", column == "created_at":
+		case strings.HasPrefix(column, "previous_first_"):
+			// first print of previous value is written once and never overwritten by revisions
+			update = append(update, fmt.Sprintf("%[1]s = COALESCE(event_schedule.%[1]s, EXCLUDED.%[1]s)", column))
+		default:
+			update = append(update, fmt.Sprintf("%[1]s = EXCLUDED.%[1]s", column))
+		}
+	}
+
+	// change_xid orders changes feed by writing transaction, inserted rows take it by default
+	update = append(update, "change_xid = txid_current()")
+
 	upsertQuery := r.initQueryBuilder().
 		Insert("event_schedule").
-		Columns("id", "timestamp_utc", "actual", "forecast", "previous", "done", "type", "event_id").
-		Values(es.Id, es.TimeStamp, es.Actual, es.Forecast, es.Previous, es.IsDone, es.Type, es.EventId).
-		Suffix("ON CONFLICT (id) DO").
-		SuffixExpr(
-			sq.Update(" ").
-				Set("timestamp_utc", es.TimeStamp).
-				Set("actual", es.Actual).
-				Set("forecast", es.Forecast).
-				Set("previous", es.Previous).
-				Set("done", es.IsDone).
-				Set("type", es.Type).
-				Set("event_id", es.EventId))
-	_, err = upsertQuery.RunWith(tx).ExecContext(ctx)
+		Columns(columns...).
+		Suffix("ON CONFLICT (id) DO UPDATE SET " + strings.Join(update, ", "))
+
+	revisionQuery := r.initQueryBuilder().
+		Insert("event_schedule_revisions").
+		Columns("event_schedule_id", "original_raw", "original_value", "revised_raw", "revised_value", "detected_at").
+		Suffix("ON CONFLICT (event_schedule_id, revised_raw) DO NOTHING")
+
+	translationsQuery := r.initQueryBuilder().
+		Insert("event_schedule_translations").
+		Columns("event_schedule_id", "language_id", "title").
+		Suffix("ON CONFLICT (event_schedule_id, language_id) DO UPDATE SET title = EXCLUDED.title")
+
+	ids := make([]int, 0, len(rows))
+	revisions, translations := 0, 0
+
+	for _, es := range rows {
+		ids = append(ids, es.Id)
+
+		values := eventScheduleInsertValues(es, hashes[es.Id], now)
+		args := make([]interface{}, len(columns))
+		for i, column := range columns {
+			args[i] = values[column]
+		}
+		upsertQuery = upsertQuery.Values(args...)
+
+		if es.PreviousRevisedFrom != nil && es.PreviousValue != nil {
+			revisionQuery = revisionQuery.Values(es.Id, truncateString(es.PreviousRevisedFrom.Raw, indexRawMaxLength), es.PreviousRevisedFrom.Value,
+				truncateString(es.PreviousValue.Raw, indexRawMaxLength), es.PreviousValue.Value, now)
+			revisions++
+		}
+
+		// translations are merged, so rows loaded for part of languages keep stored titles
+		for langId, title := range es.TitleTranslations {
+			translationsQuery = translationsQuery.Values(es.Id, langId, title)
+			translations++
+		}
+	}
+
+	stored, err := r.lockScheduleValues(ctx, tx, ids)
 	if err != nil {
+		return err
+	}
+
+	if _, err = upsertQuery.RunWith(tx).ExecContext(ctx); err != nil {
 		return fmt.Errorf("execute upsert query error: %w", err)
 	}
 
-	deleteQuery := r.initQueryBuilder().
-		Delete("event_schedule_translations").
-		Where(sq.Eq{"event_schedule_id": es.Id})
-	_, err = deleteQuery.RunWith(tx).ExecContext(ctx)
-	if err != nil {
-		return fmt.Errorf("execute delete translations query error: %w", err)
+	if err = r.saveOutboxRecords(ctx, tx, rows, stored, now); err != nil {
+		return err
 	}
 
-	for langId, title := range es.TitleTranslations {
-		insertQuery := r.initQueryBuilder().
-			Insert("event_schedule_translations").
-			Columns("event_schedule_id", "language_id", "title").
-			Values(es.Id, langId, title)
-		_, err = insertQuery.RunWith(tx).ExecContext(ctx)
-		if err != nil {
-			return fmt.Errorf("execute insert translation query: %w", err)
+	if revisions > 0 {
+		if _, err = revisionQuery.RunWith(tx).ExecContext(ctx); err != nil {
+			return fmt.Errorf("execute insert revisions query error: %w", err)
+		}
+	}
+
+	if err = r.saveVersions(ctx, tx, ids, now); err != nil {
+		return err
+	}
+
+	if translations > 0 {
+		if _, err = translationsQuery.RunWith(tx).ExecContext(ctx); err != nil {
+			return fmt.Errorf("execute upsert translations query error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// eventScheduleInsertValues returns event_schedule column values of schedule row saved at now.
+func eventScheduleInsertValues(es EventSchedule, hash string, now time.Time) map[string]interface{} {
+	values := map[string]interface{}{
+		"id":            es.Id,
+		"content_hash":  hash,
+		"created_at":    now,
+		"updated_at":    now,
+		"timestamp_utc": es.TimeStamp,
+		"actual":        es.Actual,
+		"forecast":      es.Forecast,
+		"previous":      es.Previous,
+		"done":          es.IsDone,
+		"type":          es.Type,
+		"event_id":      es.EventId,
+	}
+	setIndexValueColumns(values, "actual", es.ActualValue)
+	setIndexValueColumns(values, "forecast", es.ForecastValue)
+	setIndexValueColumns(values, "previous", es.PreviousValue)
+
+	firstPrint := es.PreviousValue
+	if es.PreviousRevisedFrom != nil {
+		firstPrint = es.PreviousRevisedFrom
+	}
+	setIndexValueColumns(values, "previous_first", firstPrint)
+
+	return values
+}
+
+// uniqueEventSchedules removes rows with repeated id keeping the last one, multi-row upsert
+// fails when the same row is affected twice.
+func uniqueEventSchedules(rows []EventSchedule) []EventSchedule {
+	index := make(map[int]int, len(rows))
+	unique := make([]EventSchedule, 0, len(rows))
+
+	for _, es := range rows {
+		if i, ok := index[es.Id]; ok {
+			unique[i] = es
+			continue
 		}
+		index[es.Id] = len(unique)
+		unique = append(unique, es)
+	}
+
+	// rows are locked in id order, so concurrent saves of overlapping batches don't deadlock
+	sort.Slice(unique, func(i, j int) bool {
+		return unique[i].Id < unique[j].Id
+	})
+
+	return unique
+}
+
+// saveVersions closes current versions of schedule rows when stored values differ from
+// them and opens new versions starting from now. Rows are expected to be already upserted.
+func (r *EventScheduleRepository) saveVersions(ctx context.Context, tx *sql.Tx, ids []int, now time.Time) (err error) {
+	unchanged := sq.Select("1").
+		From("event_schedule es").
+		Where("es.id = event_schedule_versions.event_schedule_id")
+	for _, column := range eventScheduleVersionColumns {
+		unchanged = unchanged.Where(fmt.Sprintf("es.%[1]s IS NOT DISTINCT FROM event_schedule_versions.%[1]s", column))
 	}
 
-	err = tx.Commit()
+	closeQuery := r.initQueryBuilder().
+		Update("event_schedule_versions").
+		Set("valid_to", now).
+		Where(sq.Eq{"event_schedule_id": ids, "valid_to": nil}).
+		Where(sq.Expr("NOT EXISTS (?)", unchanged))
+	_, err = closeQuery.RunWith(tx).ExecContext(ctx)
 	if err != nil {
-		return fmt.Errorf("commit transaction error: %w", err)
+		return fmt.Errorf("execute close version query error: %w", err)
+	}
+
+	current := sq.Select("1").
+		From("event_schedule_versions").
+		Where("event_schedule_versions.event_schedule_id = event_schedule.id").
+		Where(sq.Eq{"valid_to": nil})
+
+	openQuery := r.initQueryBuilder().
+		Insert("event_schedule_versions").
+		Columns(append([]string{"event_schedule_id", "valid_from"}, eventScheduleVersionColumns...)...).
+		Select(sq.Select("id").Column("?::timestamp", now).Columns(eventScheduleVersionColumns...).
+			From("event_schedule").
+			Where(sq.Eq{"id": ids}).
+			Where(sq.Expr("NOT EXISTS (?)", current)))
+	_, err = openQuery.RunWith(tx).ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("execute open version query error: %w", err)
 	}
 
 	return nil
@@ -123,7 +481,11 @@ func (r *EventScheduleRepository) getWithFilter(ctx context.Context, filter func
 	events = make([]EventSchedule, 0, 256)
 
 	query := r.initQueryBuilder().
-		Select("es.*, est.language_id, est.title").
+		Select("es.id, es.timestamp_utc, es.actual, es.forecast, es.previous, es.done, es.type, es.event_id",
+			"es.actual_raw, es.actual_value, es.actual_scale, es.actual_unit",
+			"es.forecast_raw, es.forecast_value, es.forecast_scale, es.forecast_unit",
+			"es.previous_raw, es.previous_value, es.previous_scale, es.previous_unit",
+			"est.language_id, est.title").
 		From("event_schedule es").
 		LeftJoin("event_schedule_translations est ON es.id = est.event_schedule_id").
 		OrderBy("es.id")
@@ -144,6 +506,9 @@ func (r *EventScheduleRepository) getWithFilter(ctx context.Context, filter func
 		curr      EventSchedule
 		prevId    int
 		trans     Translations
+		actual    indexValueScanner
+		forecast  indexValueScanner
+		previous  indexValueScanner
 	)
 
 	for rows.Next() {
@@ -156,6 +521,9 @@ func (r *EventScheduleRepository) getWithFilter(ctx context.Context, filter func
 			&curr.IsDone,
 			&curr.Type,
 			&curr.EventId,
+			&actual.raw, &actual.value, &actual.scale, &actual.unit,
+			&forecast.raw, &forecast.value, &forecast.scale, &forecast.unit,
+			&previous.raw, &previous.value, &previous.scale, &previous.unit,
 			&langId,
 			&langTitle,
 		)
@@ -164,6 +532,10 @@ func (r *EventScheduleRepository) getWithFilter(ctx context.Context, filter func
 			return nil, fmtError("scan row", err)
 		}
 
+		curr.ActualValue = actual.indexValue()
+		curr.ForecastValue = forecast.indexValue()
+		curr.PreviousValue = previous.indexValue()
+
 		if curr.Id != prevId {
 			trans = Translations{}
 			curr.TitleTranslations = trans
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 )
@@ -40,14 +41,24 @@ func (r *EventsRepository) GetById(ctx context.Context, id int) (*Event, error)
 	return &res[0], nil
 }
 
-func (r *EventsRepository) Save(ctx context.Context, e Event) error {
+// Save upserts event with translations, event is skipped when its content hash matches
+// stored one, so updated_at is moved only by real changes. Stored hash is read under row
+// lock in the same transaction as write.
+func (r *EventsRepository) Save(ctx context.Context, e Event) (WriteStats, error) {
 	fmtError := func(msg string, err error) error {
 		return fmt.Errorf("save event failed: %s: %w", msg, err)
 	}
 
+	stats := WriteStats{}
+
+	hash, err := contentHash(e)
+	if err != nil {
+		return stats, fmtError("content hash", err)
+	}
+
 	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
 	if err != nil {
-		return fmtError("create db transaction", err)
+		return WriteStats{}, fmtError("create db transaction", err)
 	}
 	defer func() {
 		if tx != nil && err != nil {
@@ -57,10 +68,25 @@ func (r *EventsRepository) Save(ctx context.Context, e Event) error {
 		}
 	}()
 
+	hashes, err := r.lockContentHashes(ctx, tx, "events", []int{e.Id})
+	if err != nil {
+		return WriteStats{}, fmtError("get content hash", err)
+	}
+
+	if hashes.change(e.Id, hash, &stats) == rowUnchanged {
+		if err = tx.Commit(); err != nil {
+			return WriteStats{}, fmtError("commit transaction", err)
+		}
+		stats.report("events")
+		return stats, nil
+	}
+
+	now := time.Now().UTC()
+
 	upsertQuery := r.initQueryBuilder().
 		Insert("events").
-		Columns("id", "country_id", "impact_level", "unit", "source", "source_url").
-		Values(e.Id, e.CountryId, e.ImpactLevel, e.Unit, e.Source, e.SourceUrl).
+		Columns("id", "country_id", "impact_level", "unit", "source", "source_url", "content_hash", "created_at", "updated_at").
+		Values(e.Id, e.CountryId, e.ImpactLevel, e.Unit, e.Source, e.SourceUrl, hash, now, now).
 		Suffix("ON CONFLICT (id) DO").
 		SuffixExpr(
 			sq.Update(" ").
@@ -68,12 +94,15 @@ func (r *EventsRepository) Save(ctx context.Context, e Event) error {
 				Set("impact_level", e.ImpactLevel).
 				Set("unit", e.Unit).
 				Set("source", e.Source).
-				Set("source_url", e.SourceUrl))
+				Set("source_url", e.SourceUrl).
+				Set("content_hash", hash).
+				Set("updated_at", now).
+				Set("change_xid", sq.Expr("txid_current()")))
 
 	_, err = upsertQuery.RunWith(tx).ExecContext(ctx)
 
 	if err != nil {
-		return fmtError("execute upsert event query", err)
+		return WriteStats{}, fmtError("execute upsert event query", err)
 	}
 
 	deleteQuery := r.initQueryBuilder().
@@ -83,7 +112,7 @@ func (r *EventsRepository) Save(ctx context.Context, e Event) error {
 	_, err = deleteQuery.RunWith(tx).ExecContext(ctx)
 
 	if err != nil {
-		return fmtError("execute delete event translations query", err)
+		return WriteStats{}, fmtError("execute delete event translations query", err)
 	}
 
 	for langId, title := range e.TitleTranslations {
@@ -98,17 +127,19 @@ func (r *EventsRepository) Save(ctx context.Context, e Event) error {
 		_, err = insertQuery.RunWith(tx).ExecContext(ctx)
 
 		if err != nil {
-			return fmtError("execute insert event translation query", err)
+			return WriteStats{}, fmtError("execute insert event translation query", err)
 		}
 	}
 
 	err = tx.Commit()
 
 	if err != nil {
-		return fmtError("commit transaction", err)
+		return WriteStats{}, fmtError("commit transaction", err)
 	}
 
-	return nil
+	stats.report("events")
+
+	return stats, nil
 }
 
 func (r *EventsRepository) getWithFilter(ctx context.Context, filter func(b sq.SelectBuilder) sq.SelectBuilder, fmtError func(suf string, err error) error) (events []Event, err error) {
@@ -116,7 +147,7 @@ func (r *EventsRepository) getWithFilter(ctx context.Context, filter func(b sq.S
 	events = make([]Event, 0, 16)
 
 	query := r.initQueryBuilder().
-		Select("e.*, et.language_id, et.title, et.overview").
+		Select("e.id, e.country_id, e.impact_level, e.unit, e.source, e.source_url, et.language_id, et.title, et.overview").
 		From("events e").
 		LeftJoin("event_translations et ON e.id = et.event_id").
 		OrderBy("e.id")
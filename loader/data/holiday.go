@@ -0,0 +1,10 @@
+package data
+
+import "time"
+
+type Holiday struct {
+	Id                int
+	CountryId         int
+	Date              time.Time
+	TitleTranslations Translations
+}
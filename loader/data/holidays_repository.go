@@ -0,0 +1,148 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/denis-gudim/economic-calendar/loader/metrics"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type HolidaysRepository struct {
+	baseRepository
+}
+
+func NewHolidaysRepository(db *sql.DB) *HolidaysRepository {
+	r := HolidaysRepository{}
+	r.db = db
+	return &r
+}
+
+func (r *HolidaysRepository) Save(ctx context.Context, h Holiday) error {
+	fmtError := func(msg string, err error) error {
+		return fmt.Errorf("save holiday failed: %s: %w", msg, err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
+	if err != nil {
+		return fmtError("create db transaction", err)
+	}
+	defer func() {
+		if tx != nil && err != nil {
+			if rerr := tx.Rollback(); rerr != nil {
+				err = rerr
+			}
+		}
+	}()
+
+	upsertQuery := r.initQueryBuilder().
+		Insert("holidays").
+		Columns("id", "country_id", "date").
+		Values(h.Id, h.CountryId, h.Date).
+		Suffix("ON CONFLICT (id) DO").
+		SuffixExpr(
+			sq.Update(" ").
+				Set("country_id", h.CountryId).
+				Set("date", h.Date))
+
+	_, err = upsertQuery.RunWith(tx).ExecContext(ctx)
+	if err != nil {
+		return fmtError("execute upsert holiday query", err)
+	}
+
+	// translations are merged, so holidays loaded for part of languages keep stored titles
+	for langId, title := range h.TitleTranslations {
+		upsertQuery := r.initQueryBuilder().
+			Insert("holiday_translations").
+			Columns("holiday_id", "language_id", "title").
+			Values(h.Id, langId, title).
+			Suffix("ON CONFLICT (holiday_id, language_id) DO").
+			SuffixExpr(sq.Update(" ").Set("title", title))
+
+		_, err = upsertQuery.RunWith(tx).ExecContext(ctx)
+		if err != nil {
+			return fmtError("execute upsert holiday translation query", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmtError("commit transaction", err)
+	}
+
+	metrics.RowsUpserted.WithLabelValues("holidays", "upsert").Inc()
+
+	return nil
+}
+
+// SaveBatch upserts holidays and their translations in one transaction using multi-row
+// statements of up to saveBatchSize holidays.
+func (r *HolidaysRepository) SaveBatch(ctx context.Context, holidays []Holiday) error {
+	if len(holidays) == 0 {
+		return nil
+	}
+
+	fmtError := func(msg string, err error) error {
+		return fmt.Errorf("save holidays batch failed: %s: %w", msg, err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
+	if err != nil {
+		return fmtError("create db transaction", err)
+	}
+	defer func() {
+		if tx != nil && err != nil {
+			if rerr := tx.Rollback(); rerr != nil {
+				err = rerr
+			}
+		}
+	}()
+
+	for start := 0; start < len(holidays); start += saveBatchSize {
+		end := start + saveBatchSize
+		if end > len(holidays) {
+			end = len(holidays)
+		}
+
+		upsertQuery := r.initQueryBuilder().
+			Insert("holidays").
+			Columns("id", "country_id", "date").
+			Suffix("ON CONFLICT (id) DO UPDATE SET country_id = EXCLUDED.country_id, date = EXCLUDED.date")
+
+		translationsQuery := r.initQueryBuilder().
+			Insert("holiday_translations").
+			Columns("holiday_id", "language_id", "title").
+			Suffix("ON CONFLICT (holiday_id, language_id) DO UPDATE SET title = EXCLUDED.title")
+
+		translations := 0
+
+		for _, h := range holidays[start:end] {
+			upsertQuery = upsertQuery.Values(h.Id, h.CountryId, h.Date)
+
+			// translations are merged, so holidays loaded for part of languages keep stored titles
+			for langId, title := range h.TitleTranslations {
+				translationsQuery = translationsQuery.Values(h.Id, langId, title)
+				translations++
+			}
+		}
+
+		if _, err = upsertQuery.RunWith(tx).ExecContext(ctx); err != nil {
+			return fmtError("execute upsert holidays query", err)
+		}
+
+		if translations > 0 {
+			if _, err = translationsQuery.RunWith(tx).ExecContext(ctx); err != nil {
+				return fmtError("execute upsert holiday translations query", err)
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmtError("commit transaction", err)
+	}
+
+	metrics.RowsUpserted.WithLabelValues("holidays", "upsert").Add(float64(len(holidays)))
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package data
+
+import "unicode/utf8"
+
+// lengths of event_schedule *_raw and *_unit columns
+const (
+	indexRawMaxLength  = 32
+	indexUnitMaxLength = 16
+)
+
+type IndexValue struct {
+	Raw   string
+	Value float64
+	Scale string
+	Unit  string
+}
+
+type indexValueScanner struct {
+	raw   *string
+	value *float64
+	scale *string
+	unit  *string
+}
+
+func (s *indexValueScanner) indexValue() *IndexValue {
+	if s.raw == nil {
+		return nil
+	}
+
+	v := IndexValue{Raw: *s.raw}
+
+	if s.value != nil {
+		v.Value = *s.value
+	}
+	if s.scale != nil {
+		v.Scale = *s.scale
+	}
+	if s.unit != nil {
+		v.Unit = *s.unit
+	}
+
+	return &v
+}
+
+func setIndexValueColumns(values map[string]interface{}, prefix string, v *IndexValue) {
+	if v == nil {
+		values[prefix+"_raw"] = nil
+		values[prefix+"_value"] = nil
+		values[prefix+"_scale"] = nil
+		values[prefix+"_unit"] = nil
+		return
+	}
+
+	values[prefix+"_raw"] = truncateString(v.Raw, indexRawMaxLength)
+	values[prefix+"_value"] = v.Value
+	values[prefix+"_scale"] = v.Scale
+	values[prefix+"_unit"] = truncateString(v.Unit, indexUnitMaxLength)
+}
+
+// truncateString cuts value to max characters, so single overlong value doesn't fail whole
+// multi-row insert.
+func truncateString(value string, max int) string {
+	if utf8.RuneCountInString(value) <= max {
+		return value
+	}
+	return string([]rune(value)[:max])
+}
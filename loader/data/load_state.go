@@ -0,0 +1,22 @@
+package data
+
+import "time"
+
+type LoadStatus string
+
+const (
+	LoadStatusLoading LoadStatus = "loading"
+	LoadStatusDone    LoadStatus = "done"
+	LoadStatusFailed  LoadStatus = "failed"
+)
+
+// LoadState is history loading checkpoint of single calendar day in single language.
+type LoadState struct {
+	Date       time.Time
+	LanguageId int
+	Status     LoadStatus
+	RowCount   int
+	Attempts   int
+	LastError  string
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,150 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/denis-gudim/economic-calendar/loader/metrics"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type LoadStatesRepository struct {
+	baseRepository
+}
+
+func NewLoadStatesRepository(db *sql.DB) *LoadStatesRepository {
+	r := LoadStatesRepository{}
+	r.db = db
+	return &r
+}
+
+func (r *LoadStatesRepository) GetByDates(ctx context.Context, from, to time.Time) (states []LoadState, err error) {
+	rows, err := r.initQueryBuilder().
+		Select("date", "language_id", "status", "row_count", "attempts", "last_error", "updated_at").
+		From("history_load_states").
+		Where(sq.GtOrEq{"date": from}).
+		Where(sq.LtOrEq{"date": to}).
+		OrderBy("date DESC", "language_id").
+		RunWith(r.db).
+		QueryContext(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("get load states by dates: execute select query error: %w", err)
+	}
+
+	defer rows.Close()
+
+	states = make([]LoadState, 0, 1024)
+
+	for rows.Next() {
+		var (
+			state     LoadState
+			lastError *string
+		)
+
+		err = rows.Scan(
+			&state.Date,
+			&state.LanguageId,
+			&state.Status,
+			&state.RowCount,
+			&state.Attempts,
+			&lastError,
+			&state.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("get load states by dates: scan row error: %w", err)
+		}
+
+		if lastError != nil {
+			state.LastError = *lastError
+		}
+
+		states = append(states, state)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("get load states by dates: read rows error: %w", err)
+	}
+
+	return
+}
+
+func (r *LoadStatesRepository) Save(ctx context.Context, state LoadState) error {
+	var lastError *string
+
+	if len(state.LastError) > 0 {
+		lastError = &state.LastError
+	}
+
+	upsertQuery := r.initQueryBuilder().
+		Insert("history_load_states").
+		Columns("date", "language_id", "status", "row_count", "attempts", "last_error", "updated_at").
+		Values(state.Date, state.LanguageId, state.Status, state.RowCount, state.Attempts, lastError, state.UpdatedAt).
+		Suffix("ON CONFLICT (date, language_id) DO").
+		SuffixExpr(sq.Update(" ").
+			Set("status", state.Status).
+			Set("row_count", state.RowCount).
+			Set("attempts", state.Attempts).
+			Set("last_error", lastError).
+			Set("updated_at", state.UpdatedAt))
+
+	_, err := upsertQuery.RunWith(r.db).ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("save load state: execute upsert query error: %w", err)
+	}
+
+	metrics.RowsUpserted.WithLabelValues("load_states", "upsert").Inc()
+
+	return nil
+}
+
+// Seed marks days stored before specified time as loaded, so history loading doesn't
+// fetch again days saved before load states were tracked. States are created per day and
+// language of stored schedule translations and only while states table is empty. Days with
+// undone rows are left without state, so they are loaded again and their actual values
+// aren't lost.
+func (r *LoadStatesRepository) Seed(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.seedQuery(before, time.Now().UTC()).
+		RunWith(r.db).
+		ExecContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("seed load states: execute insert query error: %w", err)
+	}
+
+	seeded, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("seed load states: get affected rows error: %w", err)
+	}
+
+	metrics.RowsUpserted.WithLabelValues("load_states", "insert").Add(float64(seeded))
+
+	return int(seeded), nil
+}
+
+func (r *LoadStatesRepository) seedQuery(before, now time.Time) sq.InsertBuilder {
+	empty := sq.Select("1").From("history_load_states")
+
+	stored := sq.Select().
+		Column("es.timestamp_utc::date").
+		Column("est.language_id").
+		Column("?", LoadStatusDone).
+		Column("COUNT(*)").
+		Column("0").
+		Column("NULL").
+		Column("?::timestamp", now).
+		From("event_schedule es").
+		Join("event_schedule_translations est ON est.event_schedule_id = es.id").
+		Where(sq.Lt{"es.timestamp_utc": before}).
+		Where(sq.Expr("NOT EXISTS (?)", empty)).
+		GroupBy("es.timestamp_utc::date", "est.language_id").
+		Having("bool_and(es.done)")
+
+	return r.initQueryBuilder().
+		Insert("history_load_states").
+		Columns("date", "language_id", "status", "row_count", "attempts", "last_error", "updated_at").
+		Select(stored).
+		Suffix("ON CONFLICT (date, language_id) DO NOTHING")
+}
@@ -0,0 +1,32 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LoadStatesRepository_SeedQuery(t *testing.T) {
+	// Arrange
+	r := NewLoadStatesRepository(nil)
+	before := time.Date(2021, 9, 20, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2021, 9, 20, 10, 0, 0, 0, time.UTC)
+
+	// Act
+	query, args, err := r.seedQuery(before, now).ToSql()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO history_load_states "+
+		"(date,language_id,status,row_count,attempts,last_error,updated_at) "+
+		"SELECT es.timestamp_utc::date, est.language_id, $1, COUNT(*), 0, NULL, $2::timestamp "+
+		"FROM event_schedule es "+
+		"JOIN event_schedule_translations est ON est.event_schedule_id = es.id "+
+		"WHERE es.timestamp_utc < $3 AND NOT EXISTS (SELECT 1 FROM history_load_states) "+
+		"GROUP BY es.timestamp_utc::date, est.language_id "+
+		// days stored ahead with undone rows aren't marked loaded, so their actual values are loaded
+		"HAVING bool_and(es.done) "+
+		"ON CONFLICT (date, language_id) DO NOTHING", query)
+	assert.Equal(t, []interface{}{LoadStatusDone, now, before}, args)
+}
@@ -0,0 +1,49 @@
+package data
+
+import "time"
+
+// ScheduleValues are event schedule values which changes are written into outbox.
+type ScheduleValues struct {
+	TimeStamp time.Time `json:"timestamp"`
+	Actual    *float64  `json:"actual"`
+	Forecast  *float64  `json:"forecast"`
+	Previous  *float64  `json:"previous"`
+	IsDone    bool      `json:"done"`
+}
+
+func newScheduleValues(es EventSchedule) ScheduleValues {
+	return ScheduleValues{
+		TimeStamp: es.TimeStamp.UTC(),
+		Actual:    es.Actual,
+		Forecast:  es.Forecast,
+		Previous:  es.Previous,
+		IsDone:    es.IsDone,
+	}
+}
+
+func (v ScheduleValues) Equal(other ScheduleValues) bool {
+	return v.TimeStamp.Equal(other.TimeStamp) &&
+		equalFloats(v.Actual, other.Actual) &&
+		equalFloats(v.Forecast, other.Forecast) &&
+		equalFloats(v.Previous, other.Previous) &&
+		v.IsDone == other.IsDone
+}
+
+func equalFloats(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// OutboxRecord is change of event schedule values written in the same transaction as
+// schedule row and delivered to publishers by outbox relay.
+type OutboxRecord struct {
+	Id              int64          `json:"id"`
+	EventScheduleId int            `json:"eventScheduleId"`
+	EventId         int            `json:"eventId"`
+	Before          ScheduleValues `json:"before"`
+	After           ScheduleValues `json:"after"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	Attempts        int            `json:"attempts"`
+}
@@ -0,0 +1,245 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type OutboxRepository struct {
+	baseRepository
+}
+
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	r := OutboxRepository{}
+	r.db = db
+	return &r
+}
+
+// GetPendingCount returns count of records which are neither published nor failed.
+func (r *OutboxRepository) GetPendingCount(ctx context.Context) (count int, err error) {
+	err = r.initQueryBuilder().
+		Select("COUNT(*)").
+		From("event_schedule_outbox").
+		Where(sq.Eq{"published_at": nil, "failed_at": nil}).
+		RunWith(r.db).
+		QueryRowContext(ctx).
+		Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("get pending outbox records count: %w", err)
+	}
+
+	return
+}
+
+// Claim marks up to limit pending records due for delivery as claimed for lease duration and
+// returns them in id order. Record is due when its next attempt time has come and it has no
+// earlier pending record of the same schedule row, so changes of a row are delivered in order
+// and failed record delays only later changes of its row. Records claimed by another relay are
+// skipped till their lease expires.
+func (r *OutboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]OutboxRecord, error) {
+	now := time.Now().UTC()
+
+	earlier := sq.Select("1").
+		From("event_schedule_outbox AS p").
+		Where("p.event_schedule_id = o.event_schedule_id").
+		Where("p.id < o.id").
+		Where(sq.Eq{"p.published_at": nil, "p.failed_at": nil})
+
+	due := sq.Select("o.id").
+		From("event_schedule_outbox AS o").
+		Where(sq.Eq{"o.published_at": nil, "o.failed_at": nil}).
+		Where(sq.LtOrEq{"o.next_attempt_at": now}).
+		Where(sq.Or{sq.Eq{"o.claimed_until": nil}, sq.Lt{"o.claimed_until": now}}).
+		Where(sq.Expr("NOT EXISTS (?)", earlier)).
+		OrderBy("o.id").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	rows, err := r.initQueryBuilder().
+		Update("event_schedule_outbox").
+		Set("claimed_until", now.Add(lease)).
+		Where(sq.Expr("id IN (?)", due)).
+		Suffix("RETURNING id, event_schedule_id, event_id, before, after, created_at, attempts").
+		RunWith(r.db).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("claim outbox records: execute update query error: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]OutboxRecord, 0, limit)
+
+	for rows.Next() {
+		var (
+			record  OutboxRecord
+			eventId sql.NullInt64
+			before  []byte
+			after   []byte
+		)
+
+		err = rows.Scan(&record.Id, &record.EventScheduleId, &eventId, &before, &after, &record.CreatedAt, &record.Attempts)
+		if err != nil {
+			return nil, fmt.Errorf("claim outbox records: scan row error: %w", err)
+		}
+
+		record.EventId = int(eventId.Int64)
+
+		if err = json.Unmarshal(before, &record.Before); err != nil {
+			return nil, fmt.Errorf("claim outbox records: unmarshal record %d before values error: %w", record.Id, err)
+		}
+
+		if err = json.Unmarshal(after, &record.After); err != nil {
+			return nil, fmt.Errorf("claim outbox records: unmarshal record %d after values error: %w", record.Id, err)
+		}
+
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("claim outbox records: read rows error: %w", err)
+	}
+
+	// returned rows order isn't defined by update statement
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Id < records[j].Id
+	})
+
+	return records, nil
+}
+
+// MarkPublished marks claimed record as published by all publishers.
+func (r *OutboxRepository) MarkPublished(ctx context.Context, record OutboxRecord) error {
+	_, err := r.initQueryBuilder().
+		Update("event_schedule_outbox").
+		Set("published_at", time.Now().UTC()).
+		Set("attempts", record.Attempts+1).
+		Set("last_error", nil).
+		Set("claimed_until", nil).
+		Where(sq.Eq{"id": record.Id}).
+		RunWith(r.db).
+		ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("mark outbox record %d published: %w", record.Id, err)
+	}
+
+	return nil
+}
+
+// MarkRetry stores failed attempt of claimed record, record is claimed again at next attempt time.
+func (r *OutboxRepository) MarkRetry(ctx context.Context, record OutboxRecord, publishErr error, nextAttemptAt time.Time) error {
+	_, err := r.initQueryBuilder().
+		Update("event_schedule_outbox").
+		Set("attempts", record.Attempts+1).
+		Set("last_error", publishErr.Error()).
+		Set("next_attempt_at", nextAttemptAt).
+		Set("claimed_until", nil).
+		Where(sq.Eq{"id": record.Id}).
+		RunWith(r.db).
+		ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("mark outbox record %d for retry: %w", record.Id, err)
+	}
+
+	return nil
+}
+
+// MarkFailed stores the last failed attempt of claimed record, record isn't relayed anymore.
+func (r *OutboxRepository) MarkFailed(ctx context.Context, record OutboxRecord, publishErr error) error {
+	_, err := r.initQueryBuilder().
+		Update("event_schedule_outbox").
+		Set("failed_at", time.Now().UTC()).
+		Set("attempts", record.Attempts+1).
+		Set("last_error", publishErr.Error()).
+		Set("claimed_until", nil).
+		Where(sq.Eq{"id": record.Id}).
+		RunWith(r.db).
+		ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("mark outbox record %d failed: %w", record.Id, err)
+	}
+
+	return nil
+}
+
+// lockScheduleValues locks stored schedule rows till the end of transaction and returns their
+// outbox tracked values by id, so concurrent saves don't write records with stale before values.
+func (r *baseRepository) lockScheduleValues(ctx context.Context, tx *sql.Tx, ids []int) (map[int]ScheduleValues, error) {
+	rows, err := r.initQueryBuilder().
+		Select("id", "timestamp_utc", "actual", "forecast", "previous", "done").
+		From("event_schedule").
+		Where(sq.Eq{"id": ids}).
+		OrderBy("id").
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("execute select schedule values query error: %w", err)
+	}
+	defer rows.Close()
+
+	values := make(map[int]ScheduleValues, len(ids))
+
+	for rows.Next() {
+		var (
+			id int
+			v  ScheduleValues
+		)
+
+		if err = rows.Scan(&id, &v.TimeStamp, &v.Actual, &v.Forecast, &v.Previous, &v.IsDone); err != nil {
+			return nil, fmt.Errorf("scan schedule values row error: %w", err)
+		}
+
+		v.TimeStamp = v.TimeStamp.UTC()
+		values[id] = v
+	}
+
+	return values, rows.Err()
+}
+
+// saveOutboxRecords writes outbox records of stored rows which tracked values are changed,
+// new rows don't produce records.
+func (r *baseRepository) saveOutboxRecords(ctx context.Context, tx *sql.Tx, rows []EventSchedule, stored map[int]ScheduleValues, now time.Time) error {
+	insertQuery := r.initQueryBuilder().
+		Insert("event_schedule_outbox").
+		Columns("event_schedule_id", "event_id", "before", "after", "created_at")
+
+	count := 0
+
+	for _, es := range rows {
+		before, ok := stored[es.Id]
+		after := newScheduleValues(es)
+
+		if !ok || before.Equal(after) {
+			continue
+		}
+
+		beforeJson, err := json.Marshal(before)
+		if err != nil {
+			return fmt.Errorf("marshal outbox record before values error: %w", err)
+		}
+
+		afterJson, err := json.Marshal(after)
+		if err != nil {
+			return fmt.Errorf("marshal outbox record after values error: %w", err)
+		}
+
+		insertQuery = insertQuery.Values(es.Id, es.EventId, string(beforeJson), string(afterJson), now)
+		count++
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	if _, err := insertQuery.RunWith(tx).ExecContext(ctx); err != nil {
+		return fmt.Errorf("execute insert outbox records query error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package emulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+type Dataset struct {
+	Countries []Country       `json:"countries"`
+	Events    []Event         `json:"events"`
+	Schedule  []ScheduleEntry `json:"schedule"`
+	Holidays  []HolidayEntry  `json:"holidays"`
+}
+
+type Country struct {
+	Id       int            `json:"id"`
+	Name     string         `json:"name"`
+	Currency string         `json:"currency"`
+	Titles   map[int]string `json:"titles"`
+}
+
+type Event struct {
+	Id          int            `json:"id"`
+	CountryName string         `json:"countryName"`
+	Sentiment   int            `json:"sentiment"`
+	Source      string         `json:"source"`
+	SourceUrl   string         `json:"sourceUrl"`
+	Titles      map[int]string `json:"titles"`
+	Overviews   map[int]string `json:"overviews"`
+}
+
+type ScheduleEntry struct {
+	Id        int            `json:"id"`
+	EventId   int            `json:"eventId"`
+	TimeStamp time.Time      `json:"timestamp"`
+	Actual    string         `json:"actual"`
+	Forecast  string         `json:"forecast"`
+	Previous  string         `json:"previous"`
+	Type      string         `json:"type"`
+	Titles    map[int]string `json:"titles"`
+
+	PreviousRevisedFrom string `json:"previousRevisedFrom"`
+}
+
+type HolidayEntry struct {
+	Id          int            `json:"id"`
+	CountryName string         `json:"countryName"`
+	Date        time.Time      `json:"date"`
+	Titles      map[int]string `json:"titles"`
+}
+
+func LoadDataset(path string) (*Dataset, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open dataset file error: %w", err)
+	}
+	defer file.Close()
+
+	dataset := Dataset{}
+
+	if err = json.NewDecoder(file).Decode(&dataset); err != nil {
+		return nil, fmt.Errorf("decode dataset file error: %w", err)
+	}
+
+	return &dataset, nil
+}
+
+func translate(titles map[int]string, languageId, defaultLanguageId int) string {
+	if title, ok := titles[languageId]; ok {
+		return title
+	}
+	return titles[defaultLanguageId]
+}
@@ -0,0 +1,303 @@
+package emulator
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/denis-gudim/economic-calendar/loader/investing"
+)
+
+const defaultLanguageId = 1
+
+var eventDetailsPathRegEx = regexp.MustCompile(`^/economic-calendar/[^/]+-(\d+)$`)
+
+// Server emulates investing.com calendar endpoints. Language is resolved from the host
+// subdomain or from the first path segment, e.g. "http://localhost:8090/%s" base url.
+type Server struct {
+	dataset   *Dataset
+	countries map[string]*Country
+	events    map[int]*Event
+	languages map[string]int
+}
+
+func NewServer(dataset *Dataset) *Server {
+	s := Server{
+		dataset:   dataset,
+		countries: make(map[string]*Country, len(dataset.Countries)),
+		events:    make(map[int]*Event, len(dataset.Events)),
+		languages: make(map[string]int, len(investing.InvestingLanguagesMap)),
+	}
+
+	for i := range dataset.Countries {
+		s.countries[dataset.Countries[i].Name] = &dataset.Countries[i]
+	}
+
+	for i := range dataset.Events {
+		s.events[dataset.Events[i].Id] = &dataset.Events[i]
+	}
+
+	for id, lang := range investing.InvestingLanguagesMap {
+		s.languages[lang.Domain] = id
+	}
+
+	return &s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	languageId, path, ok := s.resolveLanguage(req)
+
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+
+	switch {
+	case req.Method == http.MethodPost && path == "/economic-calendar/Service/getCalendarFilteredData":
+		s.serveSchedule(w, req, languageId)
+	case req.Method == http.MethodGet && (path == "/economic-calendar/" || path == "/economic-calendar"):
+		s.serveCountries(w, languageId)
+	case req.Method == http.MethodGet && eventDetailsPathRegEx.MatchString(path):
+		eventId, _ := strconv.Atoi(eventDetailsPathRegEx.FindStringSubmatch(path)[1])
+		s.serveEventDetails(w, req, languageId, eventId)
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+func (s *Server) resolveLanguage(req *http.Request) (int, string, bool) {
+	host := strings.Split(req.Host, ":")[0]
+
+	if i := strings.Index(host, "."); i > 0 {
+		if id, ok := s.languages[host[:i]]; ok {
+			return id, req.URL.Path, true
+		}
+	}
+
+	segments := strings.SplitN(strings.TrimPrefix(req.URL.Path, "/"), "/", 2)
+
+	if id, ok := s.languages[segments[0]]; ok && len(segments) == 2 {
+		return id, "/" + segments[1], true
+	}
+
+	return 0, "", false
+}
+
+func (s *Server) serveSchedule(w http.ResponseWriter, req *http.Request, languageId int) {
+	if err := req.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", req.PostForm.Get("dateFrom"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	to, err := time.Parse("2006-01-02", req.PostForm.Get("dateTo"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	days := make(map[time.Time]*dayView)
+
+	getDay := func(t time.Time) *dayView {
+		date := t.UTC().Truncate(24 * time.Hour)
+
+		if date.Before(from) || date.After(to) {
+			return nil
+		}
+
+		if _, ok := days[date]; !ok {
+			days[date] = &dayView{Date: date}
+		}
+
+		return days[date]
+	}
+
+	for _, entry := range s.dataset.Holidays {
+		day := getDay(entry.Date)
+
+		if day == nil {
+			continue
+		}
+
+		day.Holidays = append(day.Holidays, holidayView{
+			Id:          entry.Id,
+			CountryName: entry.CountryName,
+			Title:       translate(entry.Titles, languageId, defaultLanguageId),
+		})
+	}
+
+	for _, entry := range s.dataset.Schedule {
+		day := getDay(entry.TimeStamp)
+
+		if day == nil {
+			continue
+		}
+
+		view := scheduleView{ScheduleEntry: entry, Title: translate(entry.Titles, languageId, defaultLanguageId)}
+
+		if event, ok := s.events[entry.EventId]; ok {
+			view.CountryName = event.CountryName
+			view.Sentiment = event.Sentiment
+			if country, ok := s.countries[event.CountryName]; ok {
+				view.Currency = country.Currency
+			}
+		}
+
+		day.Rows = append(day.Rows, view)
+	}
+
+	views := make([]*dayView, 0, len(days))
+
+	for _, day := range days {
+		sort.Slice(day.Rows, func(i, j int) bool {
+			return day.Rows[i].TimeStamp.Before(day.Rows[j].TimeStamp)
+		})
+		views = append(views, day)
+	}
+
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].Date.Before(views[j].Date)
+	})
+
+	html := bytes.Buffer{}
+
+	if err = scheduleTemplate.Execute(&html, views); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"data": html.String(),
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.writeResponse(w, "application/json", body)
+}
+
+func (s *Server) serveEventDetails(w http.ResponseWriter, req *http.Request, languageId, eventId int) {
+	event, ok := s.events[eventId]
+
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+
+	view := eventView{
+		Event:    *event,
+		Title:    translate(event.Titles, languageId, defaultLanguageId),
+		Overview: translate(event.Overviews, languageId, defaultLanguageId),
+	}
+
+	if country, ok := s.countries[event.CountryName]; ok {
+		view.Currency = country.Currency
+	}
+
+	for _, entry := range s.dataset.Schedule {
+		if entry.EventId == eventId && !entry.TimeStamp.Before(view.TimeStamp) {
+			view.TimeStamp = entry.TimeStamp
+			view.Actual = entry.Actual
+			view.Forecast = entry.Forecast
+			view.Previous = entry.Previous
+		}
+	}
+
+	s.writeTemplate(w, eventDetailsTemplate, view)
+}
+
+func (s *Server) serveCountries(w http.ResponseWriter, languageId int) {
+	views := make([]countryView, len(s.dataset.Countries))
+
+	for i, country := range s.dataset.Countries {
+		views[i] = countryView{
+			Id:    country.Id,
+			Title: translate(country.Titles, languageId, defaultLanguageId),
+		}
+	}
+
+	s.writeTemplate(w, countriesTemplate, views)
+}
+
+func (s *Server) writeTemplate(w http.ResponseWriter, t *template.Template, data interface{}) {
+	html := bytes.Buffer{}
+
+	if err := t.Execute(&html, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.writeResponse(w, "text/html; charset=utf-8", html.Bytes())
+}
+
+func (s *Server) writeResponse(w http.ResponseWriter, contentType string, body []byte) {
+	compressed := bytes.Buffer{}
+	writer := gzip.NewWriter(&compressed)
+
+	if _, err := writer.Write(body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := writer.Close(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Encoding", "gzip")
+	w.Header().Set("Content-Length", fmt.Sprint(compressed.Len()))
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = w.Write(compressed.Bytes())
+}
+
+type dayView struct {
+	Date     time.Time
+	Holidays []holidayView
+	Rows     []scheduleView
+}
+
+type holidayView struct {
+	Id          int
+	CountryName string
+	Title       string
+}
+
+type scheduleView struct {
+	ScheduleEntry
+	Title       string
+	CountryName string
+	Currency    string
+	Sentiment   int
+}
+
+type eventView struct {
+	Event
+	Title     string
+	Overview  string
+	Currency  string
+	TimeStamp time.Time
+	Actual    string
+	Forecast  string
+	Previous  string
+}
+
+type countryView struct {
+	Id    int
+	Title string
+}
@@ -0,0 +1,188 @@
+package emulator_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/denis-gudim/economic-calendar/loader"
+	"github.com/denis-gudim/economic-calendar/loader/investing"
+	"github.com/denis-gudim/economic-calendar/loader/investing/emulator"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newInvestingRepository(t *testing.T) *investing.InvestingRepository {
+	dataset, err := emulator.LoadDataset("testdata/dataset.json")
+	require.Nil(t, err)
+
+	server := httptest.NewServer(emulator.NewServer(dataset))
+	t.Cleanup(server.Close)
+
+	cnf := &loader.Config{}
+	cnf.Loading.DefaultLanguageId = 1
+	cnf.Loading.BatchSize = 4
+	cnf.Loading.RetryCount = 1
+	cnf.Source.BaseUrl = server.URL + "/%s"
+
+	logger, _ := test.NewNullLogger()
+
+	client, err := investing.NewInvestingHttpClient(cnf)
+	require.Nil(t, err)
+
+	repository, err := investing.NewInvestingRepository(cnf, logger, client, nil, nil)
+	require.Nil(t, err)
+
+	return repository
+}
+
+func Test_Server_GetEventsSchedule(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repository := newInvestingRepository(t)
+	date := time.Date(2021, time.September, 20, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	actualResult, err := repository.GetEventsSchedule(ctx, date, date)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(actualResult))
+	assert.Equal(t, len(investing.InvestingLanguagesMap), len(actualResult[436019]))
+
+	for _, row := range actualResult[436019] {
+		assert.Equal(t, 739, row.EventId)
+		assert.Equal(t, "Germany", row.CountryName)
+		assert.Equal(t, "EUR", row.CurrencyCode)
+		assert.Equal(t, 1, row.Sentiment)
+		assert.Equal(t, 12.0, *row.Actual)
+		assert.Equal(t, 11.4, *row.Forecast)
+		assert.Equal(t, 10.4, *row.Previous)
+		assert.Equal(t, "10.2%", row.PreviousRevisedFrom.Raw)
+
+		switch row.LanguageId {
+		case 8:
+			assert.Equal(t, "Deutschland Erzeugerpreisindex (Jahr) (Aug)", row.Title)
+		default:
+			assert.Equal(t, "German PPI (YoY)  (Aug)", row.Title)
+		}
+	}
+}
+
+func Test_Server_GetCalendar(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repository := newInvestingRepository(t)
+	date := time.Date(2021, time.September, 20, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	actualResult, err := repository.GetCalendar(ctx, date, date)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(actualResult.Schedule))
+	assert.Equal(t, 1, len(actualResult.Holidays))
+	assert.Equal(t, len(investing.InvestingLanguagesMap), len(actualResult.Holidays[372]))
+
+	for _, holiday := range actualResult.Holidays[372] {
+		assert.Equal(t, "Germany", holiday.CountryName)
+		assert.Equal(t, date, holiday.Date)
+
+		switch holiday.LanguageId {
+		case 8:
+			assert.Equal(t, "Deutschland - Weltkindertag", holiday.Title)
+		default:
+			assert.Equal(t, "Germany - Children's Day", holiday.Title)
+		}
+	}
+}
+
+func Test_Server_GetCalendarByLanguage(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repository := newInvestingRepository(t)
+	date := time.Date(2021, time.September, 20, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	actualResult, err := repository.GetCalendarByLanguage(ctx, 8, date, date)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(actualResult.Schedule))
+	assert.Equal(t, 1, len(actualResult.Schedule[436019]))
+	assert.Equal(t, "Deutschland Erzeugerpreisindex (Jahr) (Aug)", actualResult.Schedule[436019][0].Title)
+	assert.Equal(t, 1, len(actualResult.Holidays[372]))
+	assert.Equal(t, "Deutschland - Weltkindertag", actualResult.Holidays[372][0].Title)
+}
+
+func Test_Server_GetEventsScheduleByLanguage(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repository := newInvestingRepository(t)
+	date := time.Date(2021, time.September, 21, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	actualResult, err := repository.GetEventsScheduleByLanguage(ctx, 4, date, date)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(actualResult))
+	assert.Equal(t, investing.PreliminaryRelease, actualResult[0].Type)
+	assert.Nil(t, actualResult[0].Actual)
+	assert.Equal(t, time.Date(2021, time.September, 21, 4, 0, 0, 0, time.UTC), actualResult[0].TimeStamp)
+}
+
+func Test_Server_GetEventDetails(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repository := newInvestingRepository(t)
+
+	// Act
+	actualResult, err := repository.GetEventDetails(ctx, 739)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, len(investing.InvestingLanguagesMap), len(actualResult))
+
+	for _, event := range actualResult {
+		assert.Equal(t, "Germany", event.Country)
+		assert.Equal(t, 1, event.Sentiment)
+		assert.Equal(t, "%", event.Unit)
+		assert.Equal(t, "Federal Statistical Office of Germany", event.Source)
+		assert.Equal(t, "https://www.destatis.de/EN/Home/_node.html", event.SourceUrl)
+	}
+}
+
+func Test_Server_GetCountries(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repository := newInvestingRepository(t)
+
+	// Act
+	actualResult, err := repository.GetCountries(ctx)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(actualResult))
+	assert.Equal(t, len(investing.InvestingLanguagesMap), len(actualResult[17]))
+	assert.Equal(t, len(investing.InvestingLanguagesMap), len(actualResult[26]))
+}
+
+func Test_Server_UnknownPath(t *testing.T) {
+	// Arrange
+	dataset, err := emulator.LoadDataset("testdata/dataset.json")
+	require.Nil(t, err)
+	server := httptest.NewServer(emulator.NewServer(dataset))
+	defer server.Close()
+
+	// Act
+	response, err := http.Get(server.URL + "/www/economic-calendar/unknown")
+
+	// Assert
+	require.Nil(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
@@ -0,0 +1,96 @@
+package emulator
+
+import "html/template"
+
+var templateFuncs = template.FuncMap{
+	"bulls": func(sentiment int) []bool {
+		bulls := make([]bool, 3)
+		for i := range bulls {
+			bulls[i] = i < sentiment
+		}
+		return bulls
+	},
+	"value": func(value string) template.HTML {
+		if len(value) == 0 {
+			return template.HTML("&nbsp;")
+		}
+		return template.HTML(template.HTMLEscapeString(value))
+	},
+}
+
+var scheduleTemplate = template.Must(template.New("schedule").Funcs(templateFuncs).Parse(`
+{{- range . -}}
+<tr><td colspan="9" class="theDay" id="theDay{{.Date.Unix}}">{{.Date.Format "Monday, January 2, 2006"}}</td></tr>
+{{range .Holidays -}}
+<tr id="eventRowId_{{.Id}}">
+	<td class="first left">All Day</td>
+	<td class="flagCur left"><span title="{{.CountryName}}" class="ceFlags" data-img_key="{{.CountryName}}">&nbsp;</span></td>
+	<td class="left textNum sentiment"><span class="bold">Holiday</span></td>
+	<td colspan="6" class="left event">{{.Title}}</td>
+</tr>
+{{end -}}
+{{range .Rows -}}
+<tr id="eventRowId_{{.Id}}" class="js-event-item" event_attr_id="{{.EventId}}" data-event-datetime="{{.TimeStamp.Format "2006/01/02 15:04:05"}}">
+	<td class="first left time js-time">{{.TimeStamp.Format "15:04"}}</td>
+	<td class="left flagCur noWrap"><span title="{{.CountryName}}" class="ceFlags" data-img_key="{{.CountryName}}">&nbsp;</span> {{.Currency}}</td>
+	<td class="left textNum sentiment noWrap">{{range bulls .Sentiment}}{{if .}}<i class="grayFullBullishIcon"></i>{{else}}<i class="grayEmptyBullishIcon"></i>{{end}}{{end}}</td>
+	<td class="left event"><a href="/economic-calendar/event-{{.EventId}}" target="_blank">{{.Title}}</a>{{if .Type}}<span class="smallGrayReport" data-img_key="{{.Type}}"></span>{{end}}</td>
+	<td class="bold act blackFont" id="eventActual_{{.Id}}">{{value .Actual}}</td>
+	<td class="fore" id="eventForecast_{{.Id}}">{{value .Forecast}}</td>
+	<td class="prev blackFont" id="eventPrevious_{{.Id}}"><span title="{{if .PreviousRevisedFrom}}Revised from {{.PreviousRevisedFrom}}{{end}}">{{value .Previous}}</span></td>
+</tr>
+{{end -}}
+{{end -}}
+`))
+
+var eventDetailsTemplate = template.Must(template.New("event").Funcs(templateFuncs).Parse(`<!DOCTYPE html>
+<html>
+<body>
+<section id="leftColumn">
+	<h1 class="ecTitle float_lang_base_1 relativeAttr">{{.Title}}</h1>
+	<div id="releaseInfo" class="releaseInfo bold">
+		<span>Actual<div class="arial_14 redFont">{{value .Actual}}</div></span>
+		<span>Forecast<div class="arial_14 noBold">{{value .Forecast}}</div></span>
+		<span>Previous<div class="arial_14 noBold blackFont">{{value .Previous}}</div></span>
+	</div>
+	<div id="overViewBox" class="overViewBox event">
+		<div class="left">{{.Overview}}</div>
+		<div class="right">
+			<div>
+				<span>Importance:</span>
+				<span>{{range bulls .Sentiment}}{{if .}}<i class="grayFullBullishIcon"></i>{{else}}<i class="grayEmptyBullishIcon"></i>{{end}}{{end}}</span>
+			</div>
+			<div>
+				<span>Country:</span>
+				<span><i title="{{.CountryName}}" class="ceFlags middle inlineblock"></i></span>
+			</div>
+			<div>
+				<span>Currency:</span>
+				<span>{{.Currency}}</span>
+			</div>
+			{{- if .Source}}
+			<div>
+				<span>Source:</span>
+				<span><a href="{{.SourceUrl}}" target="_blank" title="{{.Source}}">{{.Source}}</a></span>
+			</div>
+			{{- end}}
+		</div>
+	</div>
+</section>
+</body>
+</html>
+`))
+
+var countriesTemplate = template.Must(template.New("countries").Parse(`<!DOCTYPE html>
+<html>
+<body>
+<div id="filtersWrapper">
+	<ul class="countryOption">
+		{{- range .}}
+		<li><input type="checkbox" id="country{{.Id}}" value="{{.Id}}"><label for="country{{.Id}}">{{.Title}}</label></li>
+		{{- end}}
+	</ul>
+</div>
+</body>
+</html>
+`))
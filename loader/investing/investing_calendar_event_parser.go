@@ -10,6 +10,7 @@ import (
 
 type InvestingCalendarEventParser struct {
 	unitRegEx *regexp.Regexp
+	selectors *EventSelectors
 }
 
 func NewInvestingCalendarEventParser() *InvestingCalendarEventParser {
@@ -22,7 +23,7 @@ func (p *InvestingCalendarEventParser) ParseCalendarEventHtml(html *goquery.Docu
 	if html == nil {
 		return nil, fmt.Errorf("argument html value is nil")
 	}
-	sectionTag := html.Find("#leftColumn")
+	sectionTag := html.Find(p.getSelectors().Section)
 	if len(sectionTag.Nodes) <= 0 {
 		return nil, fmt.Errorf("invalid html. couldn't find section details node")
 	}
@@ -49,7 +50,7 @@ func (p *InvestingCalendarEventParser) ParseCalendarEventHtml(html *goquery.Docu
 }
 
 func (p *InvestingCalendarEventParser) parseTitle(s *goquery.Selection) (string, error) {
-	tag := s.Find("h1.ecTitle")
+	tag := s.Find(p.getSelectors().Title)
 	if len(tag.Nodes) <= 0 {
 		return "", errors.New("invalid html missed title header tag")
 	}
@@ -61,7 +62,7 @@ func (p *InvestingCalendarEventParser) parseTitle(s *goquery.Selection) (string,
 }
 
 func (p *InvestingCalendarEventParser) parseOverview(s *goquery.Selection) (overview string, err error) {
-	tag := s.Find("#overViewBox div.left")
+	tag := s.Find(p.getSelectors().Overview)
 	if len(tag.Nodes) > 0 {
 		overview = normalizeHtmlText(tag.Text())
 	}
@@ -69,7 +70,7 @@ func (p *InvestingCalendarEventParser) parseOverview(s *goquery.Selection) (over
 }
 
 func (p *InvestingCalendarEventParser) parseSourceInfo(s *goquery.Selection) (string, string, error) {
-	tag := s.Find("div.right div:last-child a")
+	tag := s.Find(p.getSelectors().Source)
 	if len(tag.Nodes) <= 0 {
 		return "", "", nil
 	}
@@ -85,7 +86,7 @@ func (p *InvestingCalendarEventParser) parseSourceInfo(s *goquery.Selection) (st
 }
 
 func (p *InvestingCalendarEventParser) parseUnit(s *goquery.Selection) (unit string, err error) {
-	s.Find("#releaseInfo div.arial_14").EachWithBreak(func(i int, s *goquery.Selection) bool {
+	s.Find(p.getSelectors().Unit).EachWithBreak(func(i int, s *goquery.Selection) bool {
 		indexValue := normalizeHtmlText(s.Text())
 		if len(indexValue) <= 0 {
 			return false
@@ -97,7 +98,7 @@ func (p *InvestingCalendarEventParser) parseUnit(s *goquery.Selection) (unit str
 }
 
 func (p *InvestingCalendarEventParser) parseSentiment(s *goquery.Selection) (int, error) {
-	items := s.Find("i.grayFullBullishIcon")
+	items := s.Find(p.getSelectors().Sentiment)
 	sentiment := len(items.Nodes)
 	if sentiment <= 0 || sentiment > 3 {
 		return 0, fmt.Errorf("invalid html. sentiment has invalid value %d", sentiment)
@@ -106,9 +107,17 @@ func (p *InvestingCalendarEventParser) parseSentiment(s *goquery.Selection) (int
 }
 
 func (p *InvestingCalendarEventParser) parseCountry(s *goquery.Selection) (string, error) {
-	tag := s.Find("i.ceFlags")
+	tag := s.Find(p.getSelectors().Country)
 	if len(tag.Nodes) <= 0 {
 		return "", errors.New("invalid html country tag not found")
 	}
 	return getAttrValue(tag, "title")
 }
+
+// getSelectors returns parser selectors, default profile selectors are used when not set.
+func (p *InvestingCalendarEventParser) getSelectors() *EventSelectors {
+	if p.selectors != nil {
+		return p.selectors
+	}
+	return &defaultSelectorProfile.Event
+}
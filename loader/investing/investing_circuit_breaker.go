@@ -0,0 +1,223 @@
+package investing
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/denis-gudim/economic-calendar/loader/metrics"
+)
+
+var ErrSourceUnavailable = errors.New("investing source is unavailable: circuit breaker is open")
+
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitHalfOpen
+	CircuitOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitHalfOpen:
+		return "half-open"
+	case CircuitOpen:
+		return "open"
+	}
+	return "closed"
+}
+
+type CircuitBreakerStatus struct {
+	State           CircuitState `json:"-"`
+	StateName       string       `json:"state"`
+	LoadFailures    int          `json:"loadFailures"`
+	ParseFailures   int          `json:"parseFailures"`
+	LastError       string       `json:"lastError,omitempty"`
+	OpenedAt        time.Time    `json:"openedAt,omitempty"`
+	NextProbeAt     time.Time    `json:"nextProbeAt,omitempty"`
+	ProbeInProgress bool         `json:"probeInProgress"`
+}
+
+// InvestingCircuitBreaker is source decorator which stops requests to investing after a run
+// of consecutive load or parse failures. While open, single probe request is let through
+// every probe interval, source is closed again when probe succeeds.
+type InvestingCircuitBreaker struct {
+	source        InvestingHtmlSource
+	threshold     int
+	probeInterval time.Duration
+	now           func() time.Time
+
+	mu            sync.Mutex
+	state         CircuitState
+	loadFailures  int
+	parseFailures int
+	lastError     error
+	openedAt      time.Time
+	nextProbeAt   time.Time
+}
+
+func NewInvestingCircuitBreaker(source InvestingHtmlSource, threshold int, probeInterval time.Duration) *InvestingCircuitBreaker {
+	if threshold <= 0 {
+		threshold = 1
+	}
+
+	return &InvestingCircuitBreaker{
+		source:        source,
+		threshold:     threshold,
+		probeInterval: probeInterval,
+		now:           time.Now,
+	}
+}
+
+func (b *InvestingCircuitBreaker) LoadEventsScheduleHtml(ctx context.Context, from, to time.Time, languageId int) (*goquery.Document, error) {
+	return b.do(func() (*goquery.Document, error) {
+		return b.source.LoadEventsScheduleHtml(ctx, from, to, languageId)
+	})
+}
+
+func (b *InvestingCircuitBreaker) LoadEventDetailsHtml(ctx context.Context, eventId, languageId int) (*goquery.Document, error) {
+	return b.do(func() (*goquery.Document, error) {
+		return b.source.LoadEventDetailsHtml(ctx, eventId, languageId)
+	})
+}
+
+func (b *InvestingCircuitBreaker) LoadCountriesHtml(ctx context.Context, languageId int) (*goquery.Document, error) {
+	return b.do(func() (*goquery.Document, error) {
+		return b.source.LoadCountriesHtml(ctx, languageId)
+	})
+}
+
+// ReportParseResult counts consecutive parse failures of loaded documents, layout changes
+// open the circuit same way as load failures.
+func (b *InvestingCircuitBreaker) ReportParseResult(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.parseFailures = 0
+		return
+	}
+
+	b.parseFailures++
+	b.lastError = err
+
+	if b.parseFailures >= b.threshold {
+		b.open()
+	}
+}
+
+// IsAvailable reports whether requests are let through: circuit is closed or probe is due.
+func (b *InvestingCircuitBreaker) IsAvailable() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state == CircuitClosed || (b.state == CircuitOpen && !b.now().Before(b.nextProbeAt))
+}
+
+func (b *InvestingCircuitBreaker) Status() CircuitBreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := CircuitBreakerStatus{
+		State:           b.state,
+		StateName:       b.state.String(),
+		LoadFailures:    b.loadFailures,
+		ParseFailures:   b.parseFailures,
+		ProbeInProgress: b.state == CircuitHalfOpen,
+	}
+
+	if b.lastError != nil {
+		status.LastError = b.lastError.Error()
+	}
+
+	if b.state != CircuitClosed {
+		status.OpenedAt = b.openedAt
+		status.NextProbeAt = b.nextProbeAt
+	}
+
+	return status
+}
+
+func (b *InvestingCircuitBreaker) do(load func() (*goquery.Document, error)) (*goquery.Document, error) {
+	if err := b.acquire(); err != nil {
+		return nil, err
+	}
+
+	html, err := load()
+
+	b.release(err)
+
+	return html, err
+}
+
+func (b *InvestingCircuitBreaker) acquire() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Before(b.nextProbeAt) {
+			return ErrSourceUnavailable
+		}
+		b.setState(CircuitHalfOpen)
+	case CircuitHalfOpen:
+		return ErrSourceUnavailable
+	}
+
+	return nil
+}
+
+// release counts load failures, not found response is answer of available source for
+// missing document, so it resets failures as success does.
+func (b *InvestingCircuitBreaker) release(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil || IsNotFoundError(err) {
+		b.loadFailures = 0
+		if b.state == CircuitHalfOpen {
+			b.parseFailures = 0
+			b.setState(CircuitClosed)
+		}
+		return
+	}
+
+	if errors.Is(err, context.Canceled) {
+		if b.state == CircuitHalfOpen {
+			b.setState(CircuitOpen)
+		}
+		return
+	}
+
+	b.loadFailures++
+	b.lastError = err
+
+	if b.state == CircuitHalfOpen || b.loadFailures >= b.threshold {
+		b.open()
+	}
+}
+
+func (b *InvestingCircuitBreaker) open() {
+	now := b.now()
+
+	if b.state == CircuitClosed {
+		b.openedAt = now
+	}
+
+	b.nextProbeAt = now.Add(b.probeInterval)
+	b.setState(CircuitOpen)
+}
+
+func (b *InvestingCircuitBreaker) setState(state CircuitState) {
+	if b.state == state {
+		return
+	}
+
+	b.state = state
+
+	metrics.SourceCircuitState.Set(float64(state))
+	metrics.SourceCircuitTransitions.WithLabelValues(state.String()).Inc()
+}
@@ -0,0 +1,136 @@
+package investing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCircuitBreaker(threshold int, probe time.Duration) (*InvestingCircuitBreaker, *time.Time) {
+	now := time.Date(2021, time.September, 20, 0, 0, 0, 0, time.UTC)
+	b := NewInvestingCircuitBreaker(&InvestingHtmlSourceMock{}, threshold, probe)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func Test_InvestingCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	b, _ := newTestCircuitBreaker(3, time.Minute)
+
+	// Act
+	for i := 0; i < 3; i++ {
+		b.LoadCountriesHtml(ctx, 2)
+	}
+	_, err := b.LoadCountriesHtml(ctx, 1)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrSourceUnavailable)
+	assert.False(t, b.IsAvailable())
+	assert.Equal(t, CircuitOpen, b.Status().State)
+	assert.Equal(t, 3, b.Status().LoadFailures)
+}
+
+func Test_InvestingCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	b, _ := newTestCircuitBreaker(3, time.Minute)
+
+	// Act
+	b.LoadCountriesHtml(ctx, 2)
+	b.LoadCountriesHtml(ctx, 2)
+	_, err := b.LoadCountriesHtml(ctx, 1)
+	b.LoadCountriesHtml(ctx, 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, b.IsAvailable())
+	assert.Equal(t, CircuitClosed, b.Status().State)
+	assert.Equal(t, 1, b.Status().LoadFailures)
+}
+
+func Test_InvestingCircuitBreaker_NotFoundIsNotFailure(t *testing.T) {
+	// Arrange
+	b, _ := newTestCircuitBreaker(2, time.Minute)
+	notFound := &StatusError{StatusCode: http.StatusNotFound}
+
+	// Act
+	b.release(fmt.Errorf("test error"))
+	b.release(notFound)
+	b.release(fmt.Errorf("test error"))
+	b.release(notFound)
+
+	// Assert
+	assert.True(t, b.IsAvailable())
+	assert.Equal(t, CircuitClosed, b.Status().State)
+	assert.Equal(t, 0, b.Status().LoadFailures)
+}
+
+func Test_InvestingCircuitBreaker_Probe(t *testing.T) {
+	tests := []struct {
+		name       string
+		languageId int
+		state      CircuitState
+		available  bool
+	}{
+		{name: "success closes circuit", languageId: 1, state: CircuitClosed, available: true},
+		{name: "failure reopens circuit", languageId: 2, state: CircuitOpen, available: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			b, now := newTestCircuitBreaker(1, time.Minute)
+			b.LoadCountriesHtml(ctx, 2)
+			*now = now.Add(time.Minute)
+
+			// Act
+			available := b.IsAvailable()
+			b.LoadCountriesHtml(ctx, tt.languageId)
+
+			// Assert
+			assert.True(t, available)
+			assert.Equal(t, tt.state, b.Status().State)
+			assert.Equal(t, tt.available, b.IsAvailable())
+		})
+	}
+}
+
+func Test_InvestingCircuitBreaker_HalfOpenRejectsConcurrentRequests(t *testing.T) {
+	// Arrange
+	b, now := newTestCircuitBreaker(1, time.Minute)
+	b.ReportParseResult(fmt.Errorf("test error"))
+	*now = now.Add(time.Minute)
+
+	// Act
+	probeErr := b.acquire()
+	_, err := b.LoadCountriesHtml(context.Background(), 1)
+
+	// Assert
+	assert.NoError(t, probeErr)
+	assert.ErrorIs(t, err, ErrSourceUnavailable)
+	assert.True(t, b.Status().ProbeInProgress)
+}
+
+func Test_InvestingCircuitBreaker_ParseFailuresOpenCircuit(t *testing.T) {
+	// Arrange
+	b, _ := newTestCircuitBreaker(2, time.Minute)
+
+	// Act
+	b.ReportParseResult(fmt.Errorf("test error"))
+	b.ReportParseResult(nil)
+	b.ReportParseResult(fmt.Errorf("test error"))
+	availableBefore := b.IsAvailable()
+	b.ReportParseResult(fmt.Errorf("layout changed"))
+
+	// Assert
+	assert.True(t, availableBefore)
+	assert.False(t, b.IsAvailable())
+	assert.Equal(t, 2, b.Status().ParseFailures)
+	assert.Equal(t, "layout changed", b.Status().LastError)
+}
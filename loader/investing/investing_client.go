@@ -1,7 +1,6 @@
 package investing
 
 import (
-	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -9,36 +8,106 @@ import (
 	"math/rand"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/denis-gudim/economic-calendar/loader"
+	"github.com/denis-gudim/economic-calendar/loader/metrics"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/google/uuid"
 )
 
+const DefaultInvestingBaseUrl = "https://%s.investing.com"
+
+// requestLabelsContextKey is context key of metric labels of source request, labels are
+// set by public load methods and observed by doRequest on every attempt.
+type requestLabelsContextKey struct{}
+
+type requestLabels struct {
+	endpoint string
+	language string
+}
+
+func withRequestLabels(ctx context.Context, endpoint string, languageId int) context.Context {
+	labels := requestLabels{endpoint: endpoint, language: strconv.Itoa(languageId)}
+
+	if lang, ok := InvestingLanguagesMap[languageId]; ok {
+		labels.language = lang.Code
+	}
+
+	return context.WithValue(ctx, requestLabelsContextKey{}, labels)
+}
+
+func observeRequest(ctx context.Context, start time.Time, response *http.Response, err error) {
+	labels, ok := ctx.Value(requestLabelsContextKey{}).(requestLabels)
+
+	if !ok {
+		labels = requestLabels{endpoint: "unknown", language: "unknown"}
+	}
+
+	status := "error"
+
+	if err == nil {
+		status = strconv.Itoa(response.StatusCode)
+	}
+
+	metrics.SourceRequests.WithLabelValues(labels.endpoint, labels.language, status).Inc()
+	metrics.SourceRequestDuration.WithLabelValues(labels.endpoint, labels.language).Observe(time.Since(start).Seconds())
+}
+
 type InvestingHttpClient struct {
 	RetryCount int
+	BaseUrl    string
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	limiter    *domainRateLimiter
+	client     *http.Client
+	proxies    *proxyPool
+	userAgents []string
+	maxSize    int64
 }
 
-func NewInvestingHttpClient(cnf *loader.Config) *InvestingHttpClient {
+func NewInvestingHttpClient(cnf *loader.Config) (*InvestingHttpClient, error) {
+	baseUrl := cnf.Source.BaseUrl
+
+	if len(baseUrl) == 0 {
+		baseUrl = DefaultInvestingBaseUrl
+	}
+
+	proxies, err := newProxyPool(splitList(cnf.Source.Proxies), cnf.Source.ProxyCooldown)
+	if err != nil {
+		return nil, fmt.Errorf("create investing client error: %w", err)
+	}
+
+	jar, err := newCookieJar(cnf.Source.CookieFile)
+	if err != nil {
+		return nil, fmt.Errorf("create investing client error: %w", err)
+	}
+
 	return &InvestingHttpClient{
 		RetryCount: cnf.Loading.RetryCount,
-	}
+		BaseUrl:    baseUrl,
+		MinBackoff: cnf.Source.MinBackoff,
+		MaxBackoff: cnf.Source.MaxBackoff,
+		limiter:    newDomainRateLimiter(cnf.Source.RateLimit, cnf.Source.RateBurst),
+		client:     newHttpClient(cnf.Source.Timeout, jar),
+		proxies:    proxies,
+		userAgents: parseUserAgents(cnf.Source.UserAgents),
+		maxSize:    cnf.Source.MaxResponseSize,
+	}, nil
 }
 
 func (client *InvestingHttpClient) LoadEventDetailsHtml(ctx context.Context, eventId, languageId int) (*goquery.Document, error) {
-	language := InvestingLanguagesMap[languageId]
-	url := fmt.Sprintf("https://%s.investing.com/economic-calendar/%x-%d", language.Domain, [16]byte(uuid.New()), eventId)
+	url := fmt.Sprintf("%s/economic-calendar/%x-%d", client.languageUrl(languageId), [16]byte(uuid.New()), eventId)
 
-	return client.doHtmlRequest(ctx, "GET", url, nil, nil)
+	return client.doHtmlRequest(withRequestLabels(ctx, "event", languageId), "GET", url, nil, nil)
 }
 
 func (client *InvestingHttpClient) LoadEventsScheduleHtml(ctx context.Context, from, to time.Time, languageId int) (response *goquery.Document, err error) {
 
-	language := InvestingLanguagesMap[languageId]
-	refererUrl := fmt.Sprintf("https://%s.investing.com/economic-calendar", language.Domain)
+	refererUrl := fmt.Sprintf("%s/economic-calendar", client.languageUrl(languageId))
 	requestUrl := fmt.Sprintf("%s/Service/getCalendarFilteredData", refererUrl)
 
 	headers := http.Header{
@@ -62,7 +131,7 @@ func (client *InvestingHttpClient) LoadEventsScheduleHtml(ctx context.Context, f
 		"uuid":          {uuid.New().String()},
 	}
 
-	responseJson, err := client.doJsonRequest(ctx, "POST", requestUrl, &headers, &params)
+	responseJson, err := client.doJsonRequest(withRequestLabels(ctx, "schedule", languageId), "POST", requestUrl, &headers, &params)
 
 	if err != nil {
 		return
@@ -81,10 +150,19 @@ func (client *InvestingHttpClient) LoadEventsScheduleHtml(ctx context.Context, f
 }
 
 func (client *InvestingHttpClient) LoadCountriesHtml(ctx context.Context, languageId int) (*goquery.Document, error) {
-	language := InvestingLanguagesMap[languageId]
-	url := fmt.Sprintf("https://%s.investing.com/economic-calendar/?_uid=%x", language.Domain, [16]byte(uuid.New()))
+	url := fmt.Sprintf("%s/economic-calendar/?_uid=%x", client.languageUrl(languageId), [16]byte(uuid.New()))
 
-	return client.doHtmlRequest(ctx, "GET", url, nil, nil)
+	return client.doHtmlRequest(withRequestLabels(ctx, "countries", languageId), "GET", url, nil, nil)
+}
+
+func (client *InvestingHttpClient) languageUrl(languageId int) string {
+	baseUrl := client.BaseUrl
+
+	if len(baseUrl) == 0 {
+		baseUrl = DefaultInvestingBaseUrl
+	}
+
+	return fmt.Sprintf(baseUrl, InvestingLanguagesMap[languageId].Domain)
 }
 
 func (client *InvestingHttpClient) doJsonRequest(ctx context.Context, method, url string, headers *http.Header, body *url.Values) (response map[string]interface{}, err error) {
@@ -113,31 +191,46 @@ func (client *InvestingHttpClient) doHtmlRequest(ctx context.Context, method, ur
 	return goquery.NewDocumentFromReader(reader)
 }
 
-func (client *InvestingHttpClient) doRetryRequest(ctx context.Context, method, url string, headers *http.Header, body *url.Values) (reader *gzip.Reader, err error) {
+func (client *InvestingHttpClient) doRetryRequest(ctx context.Context, method, url string, headers *http.Header, body *url.Values) (reader io.ReadCloser, err error) {
+
+	retryCount := client.RetryCount
+
+	if retryCount <= 0 {
+		retryCount = 1
+	}
 
-	for i := 0; i < client.RetryCount; i++ {
-		select {
-		case <-ctx.Done():
-			return nil, fmt.Errorf("do retry request canceled")
-		default:
-			{
-				reader, err = client.doRequest(ctx, method, url, headers, body)
+	for attempt := 0; attempt < retryCount; attempt++ {
 
-				if err == nil {
-					break
-				}
+		if attempt > 0 {
+			timer := time.NewTimer(client.retryDelay(attempt, err))
 
-				if reader != nil {
-					reader.Close()
-				}
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, fmt.Errorf("do retry request canceled: %w", ctx.Err())
+			case <-timer.C:
 			}
 		}
+
+		if err = client.limiter.Wait(ctx, url); err != nil {
+			return nil, fmt.Errorf("do retry request canceled: %w", err)
+		}
+
+		reader, err = client.doRequest(ctx, method, url, headers, body)
+
+		if err == nil {
+			return reader, nil
+		}
+
+		if !isRetryableError(err) {
+			return nil, err
+		}
 	}
 
-	return
+	return nil, fmt.Errorf("do retry request failed after %d attempts: %w", retryCount, err)
 }
 
-func (client *InvestingHttpClient) doRequest(ctx context.Context, method, url string, headers *http.Header, body *url.Values) (reader *gzip.Reader, err error) {
+func (client *InvestingHttpClient) doRequest(ctx context.Context, method, url string, headers *http.Header, body *url.Values) (reader io.ReadCloser, err error) {
 
 	var bodyReader io.Reader
 
@@ -157,26 +250,41 @@ func (client *InvestingHttpClient) doRequest(ctx context.Context, method, url st
 		request.Header.Set("Accept", "*/*")
 	}
 
-	request.Header.Set("Accept-Encoding", "gzip")
-	request.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/81.0.4044.129 Safari/537.36")
+	request.Header.Set("Accept-Encoding", acceptEncoding)
+	request.Header.Set("User-Agent", randomUserAgent(client.userAgents))
 
-	response, err := http.DefaultClient.Do(request)
+	httpClient := client.client
 
-	if err != nil {
-		return nil, fmt.Errorf("investing client do request error: %w", err)
+	if httpClient == nil {
+		httpClient = http.DefaultClient
 	}
 
-	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("investing client do request: invalid response code '%v'", response.StatusCode)
+	proxy := client.proxies.Next()
+
+	if proxy != nil {
+		request = request.WithContext(context.WithValue(ctx, proxyContextKey{}, proxy))
 	}
 
-	encoding := response.Header.Get("Content-Encoding")
+	start := time.Now()
+
+	response, err := httpClient.Do(request)
 
-	if encoding != "gzip" {
-		return nil, fmt.Errorf("investing client do request: invalid response encoding '%v'", encoding)
+	observeRequest(ctx, start, response, err)
+
+	client.proxies.Report(ctx, proxy, err)
+
+	if err != nil {
+		return nil, fmt.Errorf("investing client do request error: %w", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		// body is drained so connection can be reused for next requests
+		_, _ = io.CopyN(io.Discard, response.Body, 4096)
+		response.Body.Close()
+		return nil, newStatusError(response)
 	}
 
-	return gzip.NewReader(response.Body)
+	return decodeResponseBody(response, client.maxSize)
 }
 
 func shuffleRequestParams(body *url.Values) string {
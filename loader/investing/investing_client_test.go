@@ -1,10 +1,21 @@
 package investing
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/denis-gudim/economic-calendar/loader/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,3 +35,229 @@ func TestShuffleRequestParams(t *testing.T) {
 	// Assert
 	assert.NotEqual(t, expected, actual)
 }
+
+func TestLanguageUrl(t *testing.T) {
+	tests := []struct {
+		baseUrl  string
+		expected string
+	}{
+		{
+			baseUrl:  "",
+			expected: "https://de.investing.com",
+		},
+		{
+			baseUrl:  "http://127.0.0.1:8090/%s",
+			expected: "http://127.0.0.1:8090/de",
+		},
+	}
+
+	for _, test := range tests {
+		// Arrange
+		client := &InvestingHttpClient{BaseUrl: test.baseUrl}
+
+		// Act
+		actual := client.languageUrl(8)
+
+		// Assert
+		assert.Equal(t, test.expected, actual)
+	}
+}
+
+func newGzipHandler(statuses ...int) (http.HandlerFunc, *int32) {
+	var requests int32
+
+	return func(w http.ResponseWriter, req *http.Request) {
+		i := int(atomic.AddInt32(&requests, 1)) - 1
+
+		if i < len(statuses) && statuses[i] != http.StatusOK {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(statuses[i])
+			return
+		}
+
+		body := bytes.Buffer{}
+		writer := gzip.NewWriter(&body)
+		_, _ = writer.Write([]byte("<html><body>ok</body></html>"))
+		_ = writer.Close()
+
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(body.Bytes())
+	}, &requests
+}
+
+func TestDoRetryRequest(t *testing.T) {
+	tests := []struct {
+		statuses         []int
+		expectedRequests int32
+		expectedStatus   int
+	}{
+		{
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			expectedRequests: 3,
+		},
+		{
+			statuses:         []int{http.StatusNotFound},
+			expectedRequests: 1,
+			expectedStatus:   http.StatusNotFound,
+		},
+		{
+			statuses:         []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			expectedRequests: 3,
+			expectedStatus:   http.StatusBadGateway,
+		},
+	}
+
+	for _, test := range tests {
+		// Arrange
+		handler, requests := newGzipHandler(test.statuses...)
+		server := httptest.NewServer(handler)
+		client := &InvestingHttpClient{
+			RetryCount: 3,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: 2 * time.Millisecond,
+		}
+
+		// Act
+		doc, err := client.doHtmlRequest(context.Background(), "GET", server.URL, nil, nil)
+
+		// Assert
+		server.Close()
+		assert.Equal(t, test.expectedRequests, atomic.LoadInt32(requests))
+
+		if test.expectedStatus == 0 {
+			assert.Nil(t, err)
+			assert.Equal(t, "ok", doc.Find("body").Text())
+			continue
+		}
+
+		var se *StatusError
+		assert.True(t, errors.As(err, &se))
+		assert.Equal(t, test.expectedStatus, se.StatusCode)
+	}
+}
+
+func TestInvestingHttpClient_RequestMetrics(t *testing.T) {
+	// Arrange
+	handler, _ := newGzipHandler(http.StatusServiceUnavailable)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	client := &InvestingHttpClient{
+		RetryCount: 2,
+		BaseUrl:    server.URL + "/%s",
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 2 * time.Millisecond,
+	}
+	throttled := metrics.SourceRequests.WithLabelValues("countries", "de", "503")
+	succeeded := metrics.SourceRequests.WithLabelValues("countries", "de", "200")
+	throttledBefore := testutil.ToFloat64(throttled)
+	succeededBefore := testutil.ToFloat64(succeeded)
+
+	// Act
+	_, err := client.LoadCountriesHtml(context.Background(), 8)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 1.0, testutil.ToFloat64(throttled)-throttledBefore)
+	assert.Equal(t, 1.0, testutil.ToFloat64(succeeded)-succeededBefore)
+}
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{err: &StatusError{StatusCode: http.StatusTooManyRequests}, expected: true},
+		{err: &StatusError{StatusCode: http.StatusServiceUnavailable}, expected: true},
+		{err: fmt.Errorf("wrapped: %w", &StatusError{StatusCode: http.StatusGatewayTimeout}), expected: true},
+		{err: &StatusError{StatusCode: http.StatusNotFound}, expected: false},
+		{err: &StatusError{StatusCode: http.StatusForbidden}, expected: false},
+		{err: &url.Error{Op: "Get", URL: "http://localhost", Err: &timeoutError{}}, expected: true},
+		{err: fmt.Errorf("wrapped: %w", context.Canceled), expected: false},
+		{err: fmt.Errorf("invalid response encoding"), expected: false},
+	}
+
+	for _, test := range tests {
+		// Act
+		actual := isRetryableError(test.err)
+
+		// Assert
+		assert.Equal(t, test.expected, actual, test.err.Error())
+	}
+}
+
+type timeoutError struct{}
+
+func (e *timeoutError) Error() string   { return "timeout" }
+func (e *timeoutError) Timeout() bool   { return true }
+func (e *timeoutError) Temporary() bool { return true }
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2021, time.September, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{value: "", expected: 0},
+		{value: "120", expected: 2 * time.Minute},
+		{value: "-5", expected: 0},
+		{value: "Mon, 20 Sep 2021 12:00:30 GMT", expected: 30 * time.Second},
+		{value: "Mon, 20 Sep 2021 11:00:00 GMT", expected: 0},
+		{value: "soon", expected: 0},
+	}
+
+	for _, test := range tests {
+		// Act
+		actual := parseRetryAfter(test.value, now)
+
+		// Assert
+		assert.Equal(t, test.expected, actual, test.value)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	// Arrange
+	client := &InvestingHttpClient{MinBackoff: time.Second, MaxBackoff: 8 * time.Second}
+
+	for attempt := 1; attempt <= 6; attempt++ {
+		backoff := time.Second << (attempt - 1)
+		if backoff > 8*time.Second {
+			backoff = 8 * time.Second
+		}
+
+		// Act
+		actual := client.retryDelay(attempt, nil)
+
+		// Assert
+		assert.GreaterOrEqual(t, actual, backoff/2)
+		assert.LessOrEqual(t, actual, backoff)
+	}
+
+	// Act
+	requested := client.retryDelay(1, &StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: 5 * time.Second})
+	limited := client.retryDelay(1, &StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Minute})
+
+	// Assert
+	assert.Equal(t, 5*time.Second, requested)
+	assert.Equal(t, 8*time.Second, limited)
+}
+
+func TestDomainRateLimiter(t *testing.T) {
+	// Arrange
+	limiter := newDomainRateLimiter(1, 2)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// Act
+	first := limiter.Wait(ctx, "https://de.investing.com/economic-calendar/")
+	second := limiter.Wait(ctx, "https://www.investing.com/economic-calendar/")
+	third := limiter.Wait(ctx, "https://ru.investing.com/economic-calendar/")
+
+	// Assert
+	assert.Nil(t, first)
+	assert.Nil(t, second)
+	assert.NotNil(t, third)
+	assert.Equal(t, "investing.com", requestDomain("https://de.investing.com/economic-calendar/"))
+	assert.Equal(t, "127.0.0.1", requestDomain("http://127.0.0.1:8090/de"))
+	assert.Nil(t, newDomainRateLimiter(0, 1).Wait(ctx, "https://de.investing.com"))
+}
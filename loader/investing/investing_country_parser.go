@@ -8,11 +8,13 @@ import (
 )
 
 type InvestingCountryParser struct {
+	selectors *CountrySelectors
 }
 
 func (parser *InvestingCountryParser) parseCountryHtml(selection *goquery.Selection) (*InvestingCountry, error) {
 	var err error
-	idValueStr, exists := selection.Find("input").Attr("value")
+	sel := parser.getSelectors()
+	idValueStr, exists := selection.Find(sel.Id).Attr("value")
 	if !exists {
 		return nil, errors.New("invalid html missed value attribute or input tag")
 	}
@@ -20,28 +22,52 @@ func (parser *InvestingCountryParser) parseCountryHtml(selection *goquery.Select
 	if result.Id, err = strconv.Atoi(idValueStr); err != nil {
 		return nil, err
 	}
-	if result.Title = selection.Find("label").Text(); len(result.Title) <= 0 {
+	if result.Title = selection.Find(sel.Title).Text(); len(result.Title) <= 0 {
 		return nil, errors.New("invalid html missed text or label tag")
 	}
 	return &result, nil
 }
 
+// ParseCountriesHtml parses countries in strict mode, document fails when any of rows is failed.
 func (parser *InvestingCountryParser) ParseCountriesHtml(html *goquery.Document) ([]*InvestingCountry, error) {
+	result, err := parser.ParseCountriesRows(html)
+	if err != nil {
+		return nil, err
+	}
+	if err = StrictParsing.Err(len(result.Countries), result.Errors); err != nil {
+		return nil, err
+	}
+	return result.Countries, nil
+}
+
+// ParseCountriesRows parses every country, rows failed to parse are reported in result errors.
+func (parser *InvestingCountryParser) ParseCountriesRows(html *goquery.Document) (*CountriesParseResult, error) {
 	if html == nil {
 		return nil, errors.New("argument html value is nil")
 	}
-	countriesHtml := html.Find("#filtersWrapper ul.countryOption li")
+	countriesHtml := html.Find(parser.getSelectors().Rows)
 	if countriesHtml == nil || len(countriesHtml.Nodes) == 0 {
 		return nil, errors.New("couldn't find country tags into html")
 	}
-	countries := make([]*InvestingCountry, len(countriesHtml.Nodes))
-	countriesHtml.EachWithBreak(func(i int, s *goquery.Selection) bool {
+	result := CountriesParseResult{
+		Countries: make([]*InvestingCountry, 0, len(countriesHtml.Nodes)),
+	}
+	countriesHtml.Each(func(i int, s *goquery.Selection) {
 		country, err := parser.parseCountryHtml(s)
 		if err != nil {
-			return false
+			rowId, _ := strconv.Atoi(s.Find(parser.getSelectors().Id).AttrOr("value", ""))
+			result.Errors = append(result.Errors, &RowError{Index: i, RowId: rowId, Err: err})
+			return
 		}
-		countries[i] = country
-		return true
+		result.Countries = append(result.Countries, country)
 	})
-	return countries, nil
+	return &result, nil
+}
+
+// getSelectors returns parser selectors, default profile selectors are used when not set.
+func (parser *InvestingCountryParser) getSelectors() *CountrySelectors {
+	if parser.selectors != nil {
+		return parser.selectors
+	}
+	return &defaultSelectorProfile.Countries
 }
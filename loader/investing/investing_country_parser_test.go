@@ -123,3 +123,31 @@ func Test_InvestingCountryParser_ParseCountriesHtml(t *testing.T) {
 		assert.Equal(t, test.expectedResult, actualResult)
 	}
 }
+
+func Test_InvestingCountryParser_ParseCountriesRows(t *testing.T) {
+	// Arrange
+	html, _ := goquery.NewDocumentFromReader(strings.NewReader(`
+		<div id="filtersWrapper">
+			<ul class="countryOption">
+				<li><input value="1"><label>Text 1</label></li>
+				<li><input value="2"></li>
+				<li><label>Text 3</label></li>
+			</ul>
+		</div>`))
+	parser := &InvestingCountryParser{}
+
+	// Act
+	result, err := parser.ParseCountriesRows(html)
+	countries, strictErr := parser.ParseCountriesHtml(html)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []*InvestingCountry{{Id: 1, Title: "Text 1"}}, result.Countries)
+	assert.Len(t, result.Errors, 2)
+	assert.Equal(t, 1, result.Errors[0].Index)
+	assert.Equal(t, 2, result.Errors[0].RowId)
+	assert.Equal(t, 2, result.Errors[1].Index)
+	assert.Equal(t, 0, result.Errors[1].RowId)
+	assert.Nil(t, countries)
+	assert.EqualError(t, strictErr, "2 rows failed to parse, first row 1 (id: 2): invalid html missed text or label tag")
+}
@@ -0,0 +1,19 @@
+package investing
+
+import "time"
+
+type InvestingHoliday struct {
+	Id          int
+	LanguageId  int
+	CountryName string
+	Date        time.Time
+	Title       string
+}
+
+func (h *InvestingHoliday) GetId() int {
+	return h.Id
+}
+
+func (h *InvestingHoliday) GetLanguageId() int {
+	return h.LanguageId
+}
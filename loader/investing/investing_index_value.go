@@ -0,0 +1,28 @@
+package investing
+
+var indexScales = map[string]float64{
+	"":  1,
+	"K": 1e3,
+	"M": 1e6,
+	"B": 1e9,
+	"T": 1e12,
+}
+
+type IndexValue struct {
+	Raw    string
+	Number float64
+	Scale  string
+	Unit   string
+}
+
+func (v *IndexValue) Normalized() float64 {
+	return v.Number * indexScales[v.Scale]
+}
+
+func (v *IndexValue) number() *float64 {
+	if v == nil {
+		return nil
+	}
+	number := v.Number
+	return &number
+}
@@ -0,0 +1,82 @@
+package investing
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseMode defines how rows failed to parse are treated.
+type ParseMode int
+
+const (
+	// StrictParsing fails whole document when any of its rows is failed to parse.
+	StrictParsing ParseMode = iota
+	// LenientParsing skips failed rows and keeps parsed ones.
+	LenientParsing
+)
+
+func NewParseMode(value string) (ParseMode, error) {
+	switch strings.ToLower(value) {
+	case "", "strict":
+		return StrictParsing, nil
+	case "lenient":
+		return LenientParsing, nil
+	}
+	return StrictParsing, fmt.Errorf("unknown parse mode '%s'", value)
+}
+
+func (m ParseMode) String() string {
+	if m == LenientParsing {
+		return "lenient"
+	}
+	return "strict"
+}
+
+// Err returns document parse error for rows parse result. Lenient parsing fails
+// only when none of document rows is parsed.
+func (m ParseMode) Err(parsed int, errs RowErrors) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	if m == LenientParsing && parsed > 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// RowError is error of parsing single document row, row id is zero when it's not parsed.
+type RowError struct {
+	Index int
+	RowId int
+	Err   error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d (id: %d): %s", e.Index, e.RowId, e.Err.Error())
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// RowErrors is list of document rows failed to parse.
+type RowErrors []*RowError
+
+func (e RowErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	return fmt.Sprintf("%d rows failed to parse, first %s", len(e), e[0].Error())
+}
+
+type ScheduleParseResult struct {
+	Rows   []*InvestingScheduleRow
+	Errors RowErrors
+}
+
+type CountriesParseResult struct {
+	Countries []*InvestingCountry
+	Errors    RowErrors
+}
@@ -0,0 +1,101 @@
+package investing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+type brokenRowSource struct {
+	InvestingHtmlSourceMock
+}
+
+func (s *brokenRowSource) LoadEventsScheduleHtml(ctx context.Context, from, to time.Time, languageId int) (*goquery.Document, error) {
+	html, err := s.InvestingHtmlSourceMock.LoadEventsScheduleHtml(ctx, from, to, languageId)
+	if err == nil {
+		html.Find("#eventRowId_436019 i.grayFullBullishIcon").Remove()
+	}
+	return html, err
+}
+
+func TestNewParseMode(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected ParseMode
+		isError  bool
+	}{
+		{value: "", expected: StrictParsing},
+		{value: "strict", expected: StrictParsing},
+		{value: "Lenient", expected: LenientParsing},
+		{value: "relaxed", isError: true},
+	}
+
+	for _, test := range tests {
+		// Act
+		actual, err := NewParseMode(test.value)
+
+		// Assert
+		assert.Equal(t, test.isError, err != nil, test.value)
+		assert.Equal(t, test.expected, actual, test.value)
+	}
+}
+
+func TestParseModeErr(t *testing.T) {
+	errs := RowErrors{{Index: 1, RowId: 10, Err: fmt.Errorf("test error")}}
+
+	tests := []struct {
+		mode     ParseMode
+		parsed   int
+		errs     RowErrors
+		expected error
+	}{
+		{mode: StrictParsing, parsed: 2, errs: nil, expected: nil},
+		{mode: StrictParsing, parsed: 2, errs: errs, expected: errs},
+		{mode: LenientParsing, parsed: 2, errs: errs, expected: nil},
+		{mode: LenientParsing, parsed: 0, errs: errs, expected: errs},
+	}
+
+	for _, test := range tests {
+		// Act
+		actual := test.mode.Err(test.parsed, test.errs)
+
+		// Assert
+		assert.Equal(t, test.expected, actual, test.mode.String())
+	}
+}
+
+func Test_InvestingRepository_ParseModes(t *testing.T) {
+	tests := []struct {
+		mode     ParseMode
+		expected int
+		isError  bool
+	}{
+		{mode: StrictParsing, isError: true},
+		{mode: LenientParsing, expected: 1},
+	}
+
+	for _, tt := range tests {
+		// Arrange
+		logger, hook := test.NewNullLogger()
+		repository := &InvestingRepository{
+			source:    &brokenRowSource{},
+			parseMode: tt.mode,
+			logger:    logger,
+		}
+
+		// Act
+		rows, err := repository.GetEventsScheduleByLanguage(context.Background(), 1, time.Now(), time.Now())
+
+		// Assert
+		assert.Equal(t, tt.isError, err != nil, tt.mode.String())
+		assert.Len(t, rows, tt.expected, tt.mode.String())
+		if !tt.isError {
+			assert.Len(t, hook.AllEntries(), 1)
+		}
+	}
+}
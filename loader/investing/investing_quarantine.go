@@ -0,0 +1,259 @@
+package investing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+type DocumentKind string
+
+const (
+	ScheduleDocument     DocumentKind = "schedule"
+	EventDetailsDocument DocumentKind = "event"
+	CountriesDocument    DocumentKind = "countries"
+)
+
+// QuarantineItem describes source document which current parser failed to parse.
+type QuarantineItem struct {
+	Id            string       `json:"id"`
+	Kind          DocumentKind `json:"kind"`
+	LanguageId    int          `json:"languageId"`
+	From          *time.Time   `json:"from,omitempty"`
+	To            *time.Time   `json:"to,omitempty"`
+	EventId       int          `json:"eventId,omitempty"`
+	Error         string       `json:"error"`
+	QuarantinedAt time.Time    `json:"quarantinedAt"`
+}
+
+// QuarantineReparseResult is result of running current parser over quarantined document.
+type QuarantineReparseResult struct {
+	Item  QuarantineItem `json:"item"`
+	Fixed bool           `json:"fixed"`
+	Error string         `json:"error,omitempty"`
+}
+
+// InvestingQuarantine keeps unparseable documents with request parameters and parser error.
+// Documents are stored with the same layout as recorded ones, so quarantine directory
+// can be replayed as well. Nil quarantine drops documents.
+type InvestingQuarantine struct {
+	directory string
+	selectors *SelectorProfile
+	now       func() time.Time
+}
+
+func NewInvestingQuarantine(directory string, selectors *SelectorProfile) *InvestingQuarantine {
+	if len(directory) == 0 {
+		return nil
+	}
+
+	return &InvestingQuarantine{
+		directory: directory,
+		selectors: selectors,
+		now:       time.Now,
+	}
+}
+
+func NewScheduleQuarantineItem(languageId int, from, to time.Time) QuarantineItem {
+	return QuarantineItem{Kind: ScheduleDocument, LanguageId: languageId, From: &from, To: &to}
+}
+
+func NewEventDetailsQuarantineItem(languageId, eventId int) QuarantineItem {
+	return QuarantineItem{Kind: EventDetailsDocument, LanguageId: languageId, EventId: eventId}
+}
+
+func NewCountriesQuarantineItem(languageId int) QuarantineItem {
+	return QuarantineItem{Kind: CountriesDocument, LanguageId: languageId}
+}
+
+// Save stores document with parser error, document quarantined for the same request
+// parameters earlier is replaced.
+func (q *InvestingQuarantine) Save(item QuarantineItem, html *goquery.Document, parseErr error) error {
+	if q == nil {
+		return nil
+	}
+
+	path, err := q.documentPath(item)
+	if err != nil {
+		return err
+	}
+
+	item.Id = q.itemId(path)
+	item.Error = parseErr.Error()
+	item.QuarantinedAt = q.now().UTC()
+
+	if err = writeDocument(path, html); err != nil {
+		return fmt.Errorf("quarantine document: %w", err)
+	}
+
+	content, err := json.MarshalIndent(item, "", "  ")
+	if err != nil {
+		return fmt.Errorf("quarantine document '%s': marshal item: %w", path, err)
+	}
+
+	if err = os.WriteFile(metadataPath(path), content, 0644); err != nil {
+		return fmt.Errorf("quarantine document '%s': write item: %w", path, err)
+	}
+
+	return nil
+}
+
+// List returns quarantined items ordered by quarantine time.
+func (q *InvestingQuarantine) List() ([]QuarantineItem, error) {
+	if q == nil {
+		return nil, fmt.Errorf("quarantine directory is not configured")
+	}
+
+	items := make([]QuarantineItem, 0)
+
+	err := filepath.WalkDir(q.directory, func(path string, d os.DirEntry, err error) error {
+		if errors.Is(err, os.ErrNotExist) && path == q.directory {
+			return filepath.SkipDir
+		}
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".json" {
+			return err
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		item := QuarantineItem{}
+
+		if err = json.Unmarshal(content, &item); err != nil {
+			return fmt.Errorf("parse item '%s': %w", path, err)
+		}
+
+		items = append(items, item)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list quarantine items error: %w", err)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].QuarantinedAt.Before(items[j].QuarantinedAt)
+	})
+
+	return items, nil
+}
+
+// Reparse runs current parser over quarantined document, nil error means document is parsed.
+func (q *InvestingQuarantine) Reparse(item QuarantineItem) error {
+	path, err := q.documentPath(item)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open quarantined document '%s': %w", path, err)
+	}
+	defer file.Close()
+
+	html, err := goquery.NewDocumentFromReader(file)
+	if err != nil {
+		return fmt.Errorf("read quarantined document '%s': %w", path, err)
+	}
+
+	return q.selectors.parseDocument(item, html)
+}
+
+// ReparseAll reparses every quarantined item, parsed items are removed when remove is set.
+func (q *InvestingQuarantine) ReparseAll(remove bool) ([]QuarantineReparseResult, error) {
+	items, err := q.List()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]QuarantineReparseResult, len(items))
+
+	for i, item := range items {
+		results[i].Item = item
+
+		if err = q.Reparse(item); err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+
+		results[i].Fixed = true
+
+		if !remove {
+			continue
+		}
+
+		if err = q.Remove(item); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+func (q *InvestingQuarantine) Remove(item QuarantineItem) error {
+	path, err := q.documentPath(item)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range []string{path, metadataPath(path)} {
+		if err = os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove quarantined document '%s': %w", p, err)
+		}
+	}
+
+	return nil
+}
+
+func (q *InvestingQuarantine) documentPath(item QuarantineItem) (string, error) {
+	switch item.Kind {
+	case ScheduleDocument:
+		if item.From == nil || item.To == nil {
+			return "", fmt.Errorf("quarantine item '%s' has no schedule dates", item.Id)
+		}
+		return scheduleDocumentPath(q.directory, *item.From, *item.To, item.LanguageId), nil
+	case EventDetailsDocument:
+		return eventDetailsDocumentPath(q.directory, item.EventId, item.LanguageId), nil
+	case CountriesDocument:
+		return countriesDocumentPath(q.directory, item.LanguageId), nil
+	}
+	return "", fmt.Errorf("quarantine item '%s' has unknown kind '%s'", item.Id, item.Kind)
+}
+
+func (q *InvestingQuarantine) itemId(path string) string {
+	rel, err := filepath.Rel(q.directory, path)
+	if err != nil {
+		rel = path
+	}
+	return strings.TrimSuffix(filepath.ToSlash(rel), ".html")
+}
+
+func metadataPath(documentPath string) string {
+	return strings.TrimSuffix(documentPath, ".html") + ".json"
+}
+
+// parseDocument parses quarantined document with profile selectors, nil profile uses default one.
+func (p *SelectorProfile) parseDocument(item QuarantineItem, html *goquery.Document) (err error) {
+	switch item.Kind {
+	case ScheduleDocument:
+		parser := p.ScheduleParser()
+		if _, err = parser.ParseScheduleHtml(html, item.LanguageId); err != nil {
+			return
+		}
+		_, err = parser.ParseHolidaysHtml(html, item.LanguageId, *item.From)
+	case EventDetailsDocument:
+		_, err = p.EventParser().ParseCalendarEventHtml(html)
+	case CountriesDocument:
+		_, err = p.CountryParser().ParseCountriesHtml(html)
+	}
+	return
+}
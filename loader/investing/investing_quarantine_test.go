@@ -0,0 +1,105 @@
+package investing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type brokenCountriesSource struct {
+	InvestingHtmlSourceMock
+}
+
+func (s *brokenCountriesSource) LoadCountriesHtml(ctx context.Context, languageId int) (*goquery.Document, error) {
+	return goquery.NewDocumentFromReader(strings.NewReader(`<div id="filters"></div>`))
+}
+
+func Test_InvestingQuarantine_SaveAndReparse(t *testing.T) {
+	// Arrange
+	directory := t.TempDir()
+	quarantine := NewInvestingQuarantine(directory, nil)
+	date := time.Date(2021, time.September, 20, 0, 0, 0, 0, time.UTC)
+	quarantine.now = func() time.Time { return date }
+	broken, _ := goquery.NewDocumentFromReader(strings.NewReader(`<div id="filters"></div>`))
+	item := NewCountriesQuarantineItem(1)
+
+	// Act
+	saveErr := quarantine.Save(item, broken, fmt.Errorf("couldn't find country tags into html"))
+	items, listErr := quarantine.List()
+	brokenResults, brokenErr := quarantine.ReparseAll(true)
+
+	fixed, _ := (&InvestingHtmlSourceMock{}).LoadCountriesHtml(context.Background(), 1)
+	require.Nil(t, writeDocument(countriesDocumentPath(directory, 1), fixed))
+	fixedResults, fixedErr := quarantine.ReparseAll(true)
+	remained, remainedErr := quarantine.List()
+
+	// Assert
+	assert.Nil(t, saveErr)
+	assert.Nil(t, listErr)
+	require.Len(t, items, 1)
+	assert.Equal(t, "1/countries", items[0].Id)
+	assert.Equal(t, CountriesDocument, items[0].Kind)
+	assert.Equal(t, 1, items[0].LanguageId)
+	assert.Equal(t, "couldn't find country tags into html", items[0].Error)
+	assert.Equal(t, date, items[0].QuarantinedAt)
+
+	assert.Nil(t, brokenErr)
+	require.Len(t, brokenResults, 1)
+	assert.False(t, brokenResults[0].Fixed)
+	assert.NotEmpty(t, brokenResults[0].Error)
+
+	assert.Nil(t, fixedErr)
+	require.Len(t, fixedResults, 1)
+	assert.True(t, fixedResults[0].Fixed)
+	assert.Nil(t, remainedErr)
+	assert.Empty(t, remained)
+	assert.NoFileExists(t, countriesDocumentPath(directory, 1))
+}
+
+func Test_InvestingQuarantine_List(t *testing.T) {
+	// Arrange
+	directory := filepath.Join(t.TempDir(), "missing")
+
+	// Act
+	items, err := NewInvestingQuarantine(directory, nil).List()
+	_, nilErr := NewInvestingQuarantine("", nil).List()
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Empty(t, items)
+	assert.NotNil(t, nilErr)
+}
+
+func Test_InvestingRepository_QuarantinesUnparsedDocuments(t *testing.T) {
+	// Arrange
+	directory := t.TempDir()
+	logger, _ := test.NewNullLogger()
+	repository := &InvestingRepository{
+		defaultLanguageId: 1,
+		source:            &brokenCountriesSource{},
+		quarantine:        NewInvestingQuarantine(directory, nil),
+		logger:            logger,
+	}
+
+	// Act
+	_, err := repository.GetCountries(context.Background())
+	items, listErr := repository.quarantine.List()
+
+	// Assert
+	assert.NotNil(t, err)
+	assert.Nil(t, listErr)
+	require.Len(t, items, 1)
+	assert.Equal(t, CountriesDocument, items[0].Kind)
+	assert.Equal(t, err.Error(), items[0].Error)
+	_, statErr := os.Stat(countriesDocumentPath(directory, 1))
+	assert.Nil(t, statErr)
+}
@@ -0,0 +1,79 @@
+package investing
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"sync"
+
+	"golang.org/x/net/publicsuffix"
+	"golang.org/x/time/rate"
+)
+
+// domainRateLimiter is token bucket limit of requests per registrable domain, so every
+// language subdomain of investing.com shares the same bucket.
+type domainRateLimiter struct {
+	limit    rate.Limit
+	burst    int
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+func newDomainRateLimiter(requestsPerSecond float64, burst int) *domainRateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return &domainRateLimiter{
+		limit:    rate.Limit(requestsPerSecond),
+		burst:    burst,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+// Wait blocks until request to url domain is allowed, nil limiter doesn't limit requests.
+func (l *domainRateLimiter) Wait(ctx context.Context, requestUrl string) error {
+	if l == nil {
+		return nil
+	}
+
+	return l.domainLimiter(requestDomain(requestUrl)).Wait(ctx)
+}
+
+func (l *domainRateLimiter) domainLimiter(domain string) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limiter, ok := l.limiters[domain]
+
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[domain] = limiter
+	}
+
+	return limiter
+}
+
+func requestDomain(requestUrl string) string {
+	u, err := url.Parse(requestUrl)
+
+	if err != nil {
+		return requestUrl
+	}
+
+	host := u.Hostname()
+
+	if net.ParseIP(host) != nil {
+		return host
+	}
+
+	if domain, err := publicsuffix.EffectiveTLDPlusOne(host); err == nil {
+		return domain
+	}
+
+	return host
+}
@@ -0,0 +1,94 @@
+package investing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+type InvestingRecordingSource struct {
+	source    InvestingHtmlSource
+	directory string
+}
+
+func NewInvestingRecordingSource(source InvestingHtmlSource, directory string) *InvestingRecordingSource {
+	return &InvestingRecordingSource{
+		source:    source,
+		directory: directory,
+	}
+}
+
+func (s *InvestingRecordingSource) LoadEventsScheduleHtml(ctx context.Context, from, to time.Time, languageId int) (*goquery.Document, error) {
+	html, err := s.source.LoadEventsScheduleHtml(ctx, from, to, languageId)
+	if err != nil {
+		return nil, err
+	}
+	return html, writeDocument(scheduleDocumentPath(s.directory, from, to, languageId), html)
+}
+
+func (s *InvestingRecordingSource) LoadEventDetailsHtml(ctx context.Context, eventId, languageId int) (*goquery.Document, error) {
+	html, err := s.source.LoadEventDetailsHtml(ctx, eventId, languageId)
+	if err != nil {
+		return nil, err
+	}
+	return html, writeDocument(eventDetailsDocumentPath(s.directory, eventId, languageId), html)
+}
+
+func (s *InvestingRecordingSource) LoadCountriesHtml(ctx context.Context, languageId int) (*goquery.Document, error) {
+	html, err := s.source.LoadCountriesHtml(ctx, languageId)
+	if err != nil {
+		return nil, err
+	}
+	return html, writeDocument(countriesDocumentPath(s.directory, languageId), html)
+}
+
+// writeDocument renders document into file, file is replaced atomically.
+func writeDocument(path string, html *goquery.Document) error {
+	fmtError := func(msg string, err error) error {
+		return fmt.Errorf("record document '%s' failed: %s: %w", path, msg, err)
+	}
+
+	if html == nil {
+		return fmtError("validate document", fmt.Errorf("argument html value is nil"))
+	}
+
+	content, err := goquery.OuterHtml(html.Selection)
+	if err != nil {
+		return fmtError("render html", err)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmtError("create directory", err)
+	}
+
+	tmpPath := path + ".tmp"
+
+	if err = os.WriteFile(tmpPath, []byte(content), 0644); err != nil {
+		return fmtError("write file", err)
+	}
+
+	if err = os.Rename(tmpPath, path); err != nil {
+		return fmtError("rename file", err)
+	}
+
+	return nil
+}
+
+func scheduleDocumentPath(directory string, from, to time.Time, languageId int) string {
+	name := fmt.Sprintf("schedule_%s_%s.html", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	return filepath.Join(directory, strconv.Itoa(languageId), name)
+}
+
+func eventDetailsDocumentPath(directory string, eventId, languageId int) string {
+	name := fmt.Sprintf("event_%d.html", eventId)
+	return filepath.Join(directory, strconv.Itoa(languageId), name)
+}
+
+func countriesDocumentPath(directory string, languageId int) string {
+	return filepath.Join(directory, strconv.Itoa(languageId), "countries.html")
+}
@@ -0,0 +1,46 @@
+package investing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+type InvestingReplaySource struct {
+	directory string
+}
+
+func NewInvestingReplaySource(directory string) *InvestingReplaySource {
+	return &InvestingReplaySource{
+		directory: directory,
+	}
+}
+
+func (s *InvestingReplaySource) LoadEventsScheduleHtml(ctx context.Context, from, to time.Time, languageId int) (*goquery.Document, error) {
+	return s.replay(ctx, scheduleDocumentPath(s.directory, from, to, languageId))
+}
+
+func (s *InvestingReplaySource) LoadEventDetailsHtml(ctx context.Context, eventId, languageId int) (*goquery.Document, error) {
+	return s.replay(ctx, eventDetailsDocumentPath(s.directory, eventId, languageId))
+}
+
+func (s *InvestingReplaySource) LoadCountriesHtml(ctx context.Context, languageId int) (*goquery.Document, error) {
+	return s.replay(ctx, countriesDocumentPath(s.directory, languageId))
+}
+
+func (s *InvestingReplaySource) replay(ctx context.Context, path string) (*goquery.Document, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("replay document '%s' canceled: %w", path, err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("replay document '%s' failed: %w", path, err)
+	}
+	defer file.Close()
+
+	return goquery.NewDocumentFromReader(file)
+}
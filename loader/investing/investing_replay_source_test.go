@@ -0,0 +1,79 @@
+package investing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_InvestingReplaySource_ReplaysRecordedDocuments(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	directory := t.TempDir()
+	recorder := NewInvestingRecordingSource(&InvestingHtmlSourceMock{}, directory)
+	replay := NewInvestingReplaySource(directory)
+	date := time.Date(2021, time.September, 20, 0, 0, 0, 0, time.UTC)
+	languageId := 1
+
+	// Act
+	_, scheduleErr := recorder.LoadEventsScheduleHtml(ctx, date, date, languageId)
+	_, detailsErr := recorder.LoadEventDetailsHtml(ctx, 739, languageId)
+	_, countriesErr := recorder.LoadCountriesHtml(ctx, languageId)
+
+	scheduleHtml, replayScheduleErr := replay.LoadEventsScheduleHtml(ctx, date, date, languageId)
+	detailsHtml, replayDetailsErr := replay.LoadEventDetailsHtml(ctx, 739, languageId)
+	countriesHtml, replayCountriesErr := replay.LoadCountriesHtml(ctx, languageId)
+
+	// Assert
+	assert.Nil(t, scheduleErr)
+	assert.Nil(t, detailsErr)
+	assert.Nil(t, countriesErr)
+	assert.Nil(t, replayScheduleErr)
+	assert.Nil(t, replayDetailsErr)
+	assert.Nil(t, replayCountriesErr)
+
+	rows, err := NewInvestingScheduleParser().ParseScheduleHtml(scheduleHtml, languageId)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(rows))
+	assert.Equal(t, 436019, rows[0].Id)
+
+	event, err := NewInvestingCalendarEventParser().ParseCalendarEventHtml(detailsHtml)
+	assert.Nil(t, err)
+	assert.Equal(t, "U.K. Core Retail Sales MoM", event.Title)
+
+	countries, err := (&InvestingCountryParser{}).ParseCountriesHtml(countriesHtml)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(countries))
+}
+
+func Test_InvestingReplaySource_MissingDocument(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	replay := NewInvestingReplaySource(t.TempDir())
+
+	// Act
+	html, err := replay.LoadEventDetailsHtml(ctx, 739, 1)
+
+	// Assert
+	assert.Nil(t, html)
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func Test_InvestingRecordingSource_SkipsFailedDocuments(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	directory := t.TempDir()
+	recorder := NewInvestingRecordingSource(&InvestingHtmlSourceMock{}, directory)
+
+	// Act
+	html, err := recorder.LoadCountriesHtml(ctx, 2)
+
+	// Assert
+	assert.Nil(t, html)
+	assert.EqualError(t, err, "test error")
+	assert.NoFileExists(t, countriesDocumentPath(directory, 2))
+}
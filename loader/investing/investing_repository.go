@@ -2,14 +2,20 @@ package investing
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"sync/atomic"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/denis-gudim/economic-calendar/loader"
+	"github.com/denis-gudim/economic-calendar/loader/metrics"
 	log "github.com/sirupsen/logrus"
 )
 
+var errLanguageSkipped = errors.New("language skipped after source throttled requests")
+
 type InvestingDataEntry interface {
 	GetId() int
 	GetLanguageId() int
@@ -21,61 +27,108 @@ type InvestingHtmlSource interface {
 	LoadCountriesHtml(ctx context.Context, languageId int) (*goquery.Document, error)
 }
 
+// parseResultReporter is implemented by sources which track parse failures of loaded documents.
+type parseResultReporter interface {
+	ReportParseResult(err error)
+}
+
 type InvestingRepository struct {
 	defaultLanguageId int
 	batchSize         int
 	source            InvestingHtmlSource
+	quarantine        *InvestingQuarantine
+	selectors         *SelectorProfile
+	parseMode         ParseMode
 	logger            *log.Logger
 }
 
-func NewInvestingRepository(cnf *loader.Config, logger *log.Logger, source InvestingHtmlSource) *InvestingRepository {
+func NewInvestingRepository(cnf *loader.Config, logger *log.Logger, source InvestingHtmlSource, quarantine *InvestingQuarantine, selectors *SelectorProfile) (*InvestingRepository, error) {
+	parseMode, err := NewParseMode(cnf.Source.ParseMode)
+	if err != nil {
+		return nil, fmt.Errorf("create investing repository error: %w", err)
+	}
+
 	return &InvestingRepository{
 		defaultLanguageId: cnf.Loading.DefaultLanguageId,
 		batchSize:         cnf.Loading.BatchSize,
 		source:            source,
+		quarantine:        quarantine,
+		selectors:         selectors,
+		parseMode:         parseMode,
 		logger:            logger,
-	}
+	}, nil
+}
+
+type InvestingCalendar struct {
+	Schedule map[int][]*InvestingScheduleRow
+	Holidays map[int][]*InvestingHoliday
 }
 
 func (repository *InvestingRepository) GetEventsSchedule(ctx context.Context, dateFrom, dateTo time.Time) (itemsMap map[int][]*InvestingScheduleRow, err error) {
 
-	rows, err := repository.getItemsByLanguage(ctx, func(ctx context.Context, languageId int) ([]InvestingDataEntry, error) {
-		langItems, err := repository.GetEventsScheduleByLanguage(ctx, languageId, dateFrom, dateTo)
+	calendar, err := repository.GetCalendar(ctx, dateFrom, dateTo)
 
-		if err != nil {
-			return nil, err
-		}
+	if err != nil {
+		return
+	}
 
-		items := make([]InvestingDataEntry, len(langItems))
+	return calendar.Schedule, nil
+}
 
-		for i, item := range langItems {
-			item.LanguageId = languageId
-			items[i] = item
-		}
+func (repository *InvestingRepository) GetCalendar(ctx context.Context, dateFrom, dateTo time.Time) (calendar *InvestingCalendar, err error) {
 
-		return items, nil
+	rows, err := repository.getItemsByLanguage(ctx, func(ctx context.Context, languageId int) ([]InvestingDataEntry, error) {
+		return repository.getCalendarByLanguage(ctx, languageId, dateFrom, dateTo)
 	})
 
 	if err != nil {
 		return
 	}
 
-	count := len(InvestingLanguagesMap)
-	itemsMap = make(map[int][]*InvestingScheduleRow, len(rows)/count)
+	return newInvestingCalendar(rows, len(InvestingLanguagesMap)), nil
+}
+
+// GetCalendarByLanguage loads calendar translated to single language only.
+func (repository *InvestingRepository) GetCalendarByLanguage(ctx context.Context, languageId int, dateFrom, dateTo time.Time) (*InvestingCalendar, error) {
+
+	rows, err := repository.getCalendarByLanguage(ctx, languageId, dateFrom, dateTo)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newInvestingCalendar(rows, 1), nil
+}
+
+func newInvestingCalendar(rows []InvestingDataEntry, count int) *InvestingCalendar {
+	calendar := &InvestingCalendar{
+		Schedule: make(map[int][]*InvestingScheduleRow, len(rows)/count),
+		Holidays: make(map[int][]*InvestingHoliday),
+	}
 
 	for _, row := range rows {
 
-		row := row.(*InvestingScheduleRow)
-		items, ok := itemsMap[row.Id]
+		switch row := row.(type) {
+		case *InvestingScheduleRow:
+			items, ok := calendar.Schedule[row.Id]
 
-		if !ok {
-			items = make([]*InvestingScheduleRow, 0, count)
-		}
+			if !ok {
+				items = make([]*InvestingScheduleRow, 0, count)
+			}
 
-		itemsMap[row.Id] = append(items, row)
+			calendar.Schedule[row.Id] = append(items, row)
+		case *InvestingHoliday:
+			items, ok := calendar.Holidays[row.Id]
+
+			if !ok {
+				items = make([]*InvestingHoliday, 0, count)
+			}
+
+			calendar.Holidays[row.Id] = append(items, row)
+		}
 	}
 
-	return
+	return calendar
 }
 
 func (repository *InvestingRepository) GetEventDetails(ctx context.Context, eventId int) (items []*InvestingCalendarEvent, err error) {
@@ -97,6 +150,17 @@ func (repository *InvestingRepository) GetEventDetails(ctx context.Context, even
 	return
 }
 
+// GetEventDetailsByLanguage loads event details translated to single language only.
+func (r *InvestingRepository) GetEventDetailsByLanguage(ctx context.Context, eventId, languageId int) (*InvestingCalendarEvent, error) {
+	items, err := r.getEventDetailsByLanguage(ctx, languageId, eventId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return items[0].(*InvestingCalendarEvent), nil
+}
+
 func (repository *InvestingRepository) GetCountries(ctx context.Context) (itemsMap map[int][]*InvestingCountry, err error) {
 
 	rows, err := repository.getItemsByLanguage(ctx, func(ctx context.Context, languageId int) ([]InvestingDataEntry, error) {
@@ -133,7 +197,92 @@ func (r *InvestingRepository) GetEventsScheduleByLanguage(ctx context.Context, l
 		return
 	}
 
-	return NewInvestingScheduleParser().ParseScheduleHtml(html, languageId)
+	result, err := r.selectors.ScheduleParser().ParseScheduleRows(html, languageId)
+
+	if err == nil {
+		err = r.checkRows(NewScheduleQuarantineItem(languageId, dateFrom, dateTo), html, len(result.Rows), result.Errors)
+	} else {
+		r.reportParseResult(err)
+		r.quarantineDocument(NewScheduleQuarantineItem(languageId, dateFrom, dateTo), html, err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Rows, nil
+}
+
+// ParseEventsScheduleByLanguage loads schedule translated to single language and returns parsed
+// rows together with row errors whatever parse mode is, so callers can see rows lenient parsing
+// would skip. Document with failed rows is quarantined.
+func (r *InvestingRepository) ParseEventsScheduleByLanguage(ctx context.Context, languageId int, dateFrom, dateTo time.Time) (*ScheduleParseResult, error) {
+
+	html, err := r.source.LoadEventsScheduleHtml(ctx, dateFrom, dateTo, languageId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	item := NewScheduleQuarantineItem(languageId, dateFrom, dateTo)
+
+	result, err := r.selectors.ScheduleParser().ParseScheduleRows(html, languageId)
+
+	if err != nil {
+		r.reportParseResult(err)
+		r.quarantineDocument(item, html, err)
+		return nil, err
+	}
+
+	if len(result.Errors) > 0 {
+		r.quarantineDocument(item, html, result.Errors)
+	}
+
+	return result, nil
+}
+
+func (r *InvestingRepository) getCalendarByLanguage(ctx context.Context, languageId int, dateFrom, dateTo time.Time) ([]InvestingDataEntry, error) {
+
+	html, err := r.source.LoadEventsScheduleHtml(ctx, dateFrom, dateTo, languageId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	parser := r.selectors.ScheduleParser()
+
+	item := NewScheduleQuarantineItem(languageId, dateFrom, dateTo)
+
+	result, err := parser.ParseScheduleRows(html, languageId)
+
+	if err == nil {
+		var holidays []*InvestingHoliday
+		if holidays, err = parser.ParseHolidaysHtml(html, languageId, dateFrom); err == nil {
+			if err = r.checkRows(item, html, len(result.Rows), result.Errors); err != nil {
+				return nil, err
+			}
+			return newCalendarItems(result.Rows, holidays), nil
+		}
+	}
+
+	r.reportParseResult(err)
+	r.quarantineDocument(item, html, err)
+
+	return nil, err
+}
+
+func newCalendarItems(rows []*InvestingScheduleRow, holidays []*InvestingHoliday) []InvestingDataEntry {
+	items := make([]InvestingDataEntry, 0, len(rows)+len(holidays))
+
+	for _, row := range rows {
+		items = append(items, row)
+	}
+
+	for _, holiday := range holidays {
+		items = append(items, holiday)
+	}
+
+	return items
 }
 
 func (r *InvestingRepository) getEventDetailsByLanguage(ctx context.Context, languageId, eventId int) ([]InvestingDataEntry, error) {
@@ -141,7 +290,9 @@ func (r *InvestingRepository) getEventDetailsByLanguage(ctx context.Context, lan
 	if err != nil {
 		return nil, err
 	}
-	event, err := NewInvestingCalendarEventParser().ParseCalendarEventHtml(html)
+	event, err := r.selectors.EventParser().ParseCalendarEventHtml(html)
+	r.reportParseResult(err)
+	r.quarantineDocument(NewEventDetailsQuarantineItem(languageId, eventId), html, err)
 	if err != nil {
 		return nil, err
 	}
@@ -156,17 +307,24 @@ func (r *InvestingRepository) getCountriesByLanguage(ctx context.Context, langua
 		return
 	}
 
-	parser := &InvestingCountryParser{}
+	parser := r.selectors.CountryParser()
+
+	result, err := parser.ParseCountriesRows(html)
 
-	rows, err := parser.ParseCountriesHtml(html)
+	if err == nil {
+		err = r.checkRows(NewCountriesQuarantineItem(languageId), html, len(result.Countries), result.Errors)
+	} else {
+		r.reportParseResult(err)
+		r.quarantineDocument(NewCountriesQuarantineItem(languageId), html, err)
+	}
 
 	if err != nil {
 		return
 	}
 
-	items = make([]InvestingDataEntry, len(rows))
+	items = make([]InvestingDataEntry, len(result.Countries))
 
-	for i, row := range rows {
+	for i, row := range result.Countries {
 		row.LanguageId = languageId
 		items[i] = row
 	}
@@ -197,21 +355,38 @@ func (r *InvestingRepository) getItemsByLanguage(ctx context.Context, itemsGette
 		defaultLanguageItemsMap[item.GetId()] = item
 	}
 
+	// once source throttles requests remaining languages are not requested,
+	// partially translated items are completed by next loading
+	var throttled atomic.Bool
+
 	_itemsGetter := func(lang *InvestingLanguage) ([]InvestingDataEntry, error) {
 
+		if throttled.Load() {
+			return nil, errLanguageSkipped
+		}
+
 		langItems, e := itemsGetter(ctx, lang.Id)
 
+		if IsThrottledError(e) {
+			throttled.Store(true)
+		}
+
 		if e != nil {
 			return nil, e
 		}
 
+		// lenient parsing may skip different rows in every language, so rows are matched by id
+		if r.parseMode == LenientParsing {
+			return r.matchLanguageItems(lang, defaultLanguageItemsMap, langItems), nil
+		}
+
 		langItemsCount := len(langItems)
 
 		if defLangItemsCount != langItemsCount {
 			return nil, fmt.Errorf("items count not equals to default lang items %d/%d", langItemsCount, defLangItemsCount)
 		}
 
-		for _, item := range items {
+		for _, item := range langItems {
 			if _, ok := defaultLanguageItemsMap[item.GetId()]; !ok {
 				return nil, fmt.Errorf("items have different keys with default items")
 			}
@@ -242,7 +417,15 @@ func (r *InvestingRepository) getItemsByLanguage(ctx context.Context, itemsGette
 
 			langItems, e := _itemsGetter(lang)
 
-			if e != nil {
+			switch {
+			case e == nil:
+			case errors.Is(e, errLanguageSkipped):
+				r.logger.Debugf("items loading for language '%s' skipped. source throttles requests", lang.Code)
+			case IsNotFoundError(e):
+				r.logger.Warnf("items loading for language '%s' skipped. %s", lang.Code, e.Error())
+			case IsThrottledError(e):
+				r.logger.Warnf("items loading for language '%s' throttled. %s", lang.Code, e.Error())
+			default:
 				r.logger.Errorf("items loading for language '%s' failed. %s", lang.Code, e.Error())
 			}
 
@@ -263,3 +446,94 @@ func (r *InvestingRepository) getItemsByLanguage(ctx context.Context, itemsGette
 
 	return
 }
+
+// matchLanguageItems returns language items which ids are present in default language items.
+// Ids missing in either language are logged, rows missing in language are completed by next
+// loading as partially translated ones.
+func (r *InvestingRepository) matchLanguageItems(lang *InvestingLanguage, defaultItems map[int]InvestingDataEntry, langItems []InvestingDataEntry) []InvestingDataEntry {
+	matched := make([]InvestingDataEntry, 0, len(langItems))
+	langIds := make(map[int]struct{}, len(langItems))
+	extra := make([]int, 0)
+
+	for _, item := range langItems {
+		langIds[item.GetId()] = struct{}{}
+
+		if _, ok := defaultItems[item.GetId()]; !ok {
+			extra = append(extra, item.GetId())
+			continue
+		}
+
+		matched = append(matched, item)
+	}
+
+	missing := make([]int, 0)
+
+	for id := range defaultItems {
+		if _, ok := langIds[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing)+len(extra) > 0 {
+		sort.Ints(missing)
+		sort.Ints(extra)
+
+		r.logger.WithFields(log.Fields{
+			"missing": missing,
+			"extra":   extra,
+		}).Warnf("items of language '%s' don't match default language items, %d of %d items matched", lang.Code, len(matched), len(defaultItems))
+	}
+
+	return matched
+}
+
+// checkRows applies parse mode to document rows parse result. Skipped rows are logged and
+// counted, document with failed rows is quarantined in both modes.
+func (r *InvestingRepository) checkRows(item QuarantineItem, html *goquery.Document, parsed int, errs RowErrors) error {
+	err := r.parseMode.Err(parsed, errs)
+
+	r.reportParseResult(err)
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	r.quarantineDocument(item, html, errs)
+
+	if err != nil {
+		return err
+	}
+
+	lang := InvestingLanguagesMap[item.LanguageId]
+
+	for _, rowErr := range errs {
+		r.logger.Warnf("%s row skipped for language '%s'. %s", item.Kind, lang.Code, rowErr.Error())
+	}
+
+	metrics.SourceSkippedRows.WithLabelValues(string(item.Kind)).Add(float64(len(errs)))
+
+	return nil
+}
+
+// quarantineDocument counts parse error and keeps document failed to parse for later diagnostics.
+func (r *InvestingRepository) quarantineDocument(item QuarantineItem, html *goquery.Document, parseErr error) {
+	if parseErr == nil {
+		return
+	}
+
+	metrics.SourceParseErrors.WithLabelValues(string(item.Kind)).Inc()
+
+	if r.quarantine == nil {
+		return
+	}
+
+	if err := r.quarantine.Save(item, html, parseErr); err != nil {
+		r.logger.Errorf("quarantine of unparsed document failed. %s", err.Error())
+	}
+}
+
+func (r *InvestingRepository) reportParseResult(err error) {
+	if reporter, ok := r.source.(parseResultReporter); ok {
+		reporter.ReportParseResult(err)
+	}
+}
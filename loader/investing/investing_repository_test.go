@@ -246,3 +246,71 @@ func Test_InvestingRepository_GetCountries(t *testing.T) {
 	assert.Equal(t, logrus.ErrorLevel, hook.LastEntry().Level)
 	assert.Contains(t, hook.LastEntry().Message, "test error")
 }
+
+func Test_InvestingRepository_GetCalendar(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	logger, _ := test.NewNullLogger()
+	source := &InvestingHtmlSourceMock{}
+	repository := &InvestingRepository{
+		source:            source,
+		defaultLanguageId: 1,
+		batchSize:         3,
+		logger:            logger,
+	}
+	date := time.Date(2021, time.September, 20, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	actualResult, err := repository.GetCalendar(ctx, date, date)
+
+	// Assert
+	source.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(actualResult.Schedule))
+	assert.Equal(t, 1, len(actualResult.Holidays))
+	assert.Equal(t, len(InvestingLanguagesMap)-1, len(actualResult.Holidays[372]))
+	assert.Equal(t, "South Korea", actualResult.Holidays[372][0].CountryName)
+	assert.Equal(t, date, actualResult.Holidays[372][0].Date)
+}
+
+func Test_InvestingRepository_getItemsByLanguage_LenientMatchesIds(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	logger, hook := test.NewNullLogger()
+	repository := &InvestingRepository{
+		defaultLanguageId: 1,
+		batchSize:         3,
+		parseMode:         LenientParsing,
+		logger:            logger,
+	}
+	itemsGetter := func(ctx context.Context, languageId int) ([]InvestingDataEntry, error) {
+		ids := []int{1, 2, 3}
+		if languageId == 2 {
+			// row 2 is skipped by lenient parsing and row 4 is skipped in default language
+			ids = []int{1, 3, 4}
+		}
+		items := make([]InvestingDataEntry, len(ids))
+		for i, id := range ids {
+			items[i] = &InvestingCountry{Id: id, LanguageId: languageId}
+		}
+		return items, nil
+	}
+
+	// Act
+	actualResult, err := repository.getItemsByLanguage(ctx, itemsGetter)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, 3*(len(InvestingLanguagesMap)-1)+2, len(actualResult))
+
+	for _, item := range actualResult {
+		if item.GetLanguageId() == 2 {
+			assert.Contains(t, []int{1, 3}, item.GetId())
+		}
+	}
+
+	assert.Equal(t, 1, len(hook.Entries))
+	assert.Equal(t, logrus.WarnLevel, hook.LastEntry().Level)
+	assert.Equal(t, []int{2}, hook.LastEntry().Data["missing"])
+	assert.Equal(t, []int{4}, hook.LastEntry().Data["extra"])
+}
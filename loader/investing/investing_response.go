@@ -0,0 +1,130 @@
+package investing
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+const (
+	acceptEncoding         = "gzip, deflate, br"
+	defaultMaxResponseSize = 32 << 20
+)
+
+var ErrResponseTooLarge = errors.New("investing client do request: response body exceeds maximum size")
+
+// responseBody is decoded response body which closes decoder and underlying
+// connection body together.
+type responseBody struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (b *responseBody) Close() (err error) {
+	for _, closer := range b.closers {
+		if e := closer.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return
+}
+
+// limitedReader fails with ErrResponseTooLarge instead of truncating body silently.
+type limitedReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	if r.remaining < 0 {
+		return 0, ErrResponseTooLarge
+	}
+
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+
+	if r.remaining < 0 {
+		return n, ErrResponseTooLarge
+	}
+
+	return n, err
+}
+
+// decodeResponseBody returns reader of response body decoded according to Content-Encoding
+// and limited to maxSize decoded bytes. Response body is closed when decoding fails.
+func decodeResponseBody(response *http.Response, maxSize int64) (io.ReadCloser, error) {
+	if maxSize <= 0 {
+		maxSize = defaultMaxResponseSize
+	}
+
+	if response.ContentLength > maxSize {
+		response.Body.Close()
+		return nil, ErrResponseTooLarge
+	}
+
+	body := &responseBody{closers: []io.Closer{response.Body}}
+
+	// encoded body is limited as well, so corrupted stream can't be read endlessly
+	encoded := &limitedReader{reader: response.Body, remaining: maxSize}
+
+	encoding := strings.ToLower(strings.TrimSpace(response.Header.Get("Content-Encoding")))
+
+	switch encoding {
+	case "", "identity":
+		body.Reader = encoded
+	case "gzip", "x-gzip":
+		reader, err := gzip.NewReader(encoded)
+		if err != nil {
+			response.Body.Close()
+			return nil, fmt.Errorf("investing client do request: create gzip reader: %w", err)
+		}
+		body.Reader = reader
+		body.closers = append([]io.Closer{reader}, body.closers...)
+	case "deflate":
+		reader, err := newDeflateReader(encoded)
+		if err != nil {
+			response.Body.Close()
+			return nil, fmt.Errorf("investing client do request: create deflate reader: %w", err)
+		}
+		body.Reader = reader
+		body.closers = append([]io.Closer{reader}, body.closers...)
+	case "br":
+		body.Reader = brotli.NewReader(encoded)
+	default:
+		response.Body.Close()
+		return nil, fmt.Errorf("investing client do request: invalid response encoding '%v'", encoding)
+	}
+
+	body.Reader = &limitedReader{reader: body.Reader, remaining: maxSize}
+
+	return body, nil
+}
+
+// newDeflateReader reads zlib wrapped stream as specified for deflate encoding, some
+// servers send raw deflate stream instead so header is checked first.
+func newDeflateReader(r io.Reader) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(r)
+
+	header, err := buffered.Peek(2)
+	if err != nil {
+		return nil, err
+	}
+
+	if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(buffered)
+	}
+
+	return flate.NewReader(buffered), nil
+}
@@ -0,0 +1,175 @@
+package investing
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type closeTrackingBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *closeTrackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func encodeBody(t *testing.T, encoding string, content []byte) []byte {
+	body := bytes.Buffer{}
+
+	var writer io.WriteCloser
+
+	switch encoding {
+	case "gzip":
+		writer = gzip.NewWriter(&body)
+	case "deflate":
+		writer = zlib.NewWriter(&body)
+	case "raw-deflate":
+		writer, _ = flate.NewWriter(&body, flate.DefaultCompression)
+	case "br":
+		writer = brotli.NewWriter(&body)
+	default:
+		return content
+	}
+
+	_, err := writer.Write(content)
+	require.Nil(t, err)
+	require.Nil(t, writer.Close())
+
+	return body.Bytes()
+}
+
+func newEncodedResponse(t *testing.T, encoding, header string, content []byte) (*http.Response, *closeTrackingBody) {
+	body := &closeTrackingBody{Reader: bytes.NewReader(encodeBody(t, encoding, content))}
+	response := &http.Response{
+		StatusCode:    http.StatusOK,
+		Header:        http.Header{},
+		Body:          body,
+		ContentLength: -1,
+	}
+
+	if len(header) > 0 {
+		response.Header.Set("Content-Encoding", header)
+	}
+
+	return response, body
+}
+
+func TestDecodeResponseBody(t *testing.T) {
+	tests := []struct {
+		encoding string
+		header   string
+	}{
+		{encoding: "identity", header: ""},
+		{encoding: "identity", header: "identity"},
+		{encoding: "gzip", header: "gzip"},
+		{encoding: "deflate", header: "deflate"},
+		{encoding: "raw-deflate", header: "deflate"},
+		{encoding: "br", header: "br"},
+	}
+
+	content := []byte(strings.Repeat("<tr><td>economic calendar</td></tr>", 64))
+
+	for _, test := range tests {
+		// Arrange
+		response, body := newEncodedResponse(t, test.encoding, test.header, content)
+
+		// Act
+		reader, err := decodeResponseBody(response, 0)
+		require.Nil(t, err, test.encoding)
+		actual, readErr := io.ReadAll(reader)
+		closeErr := reader.Close()
+
+		// Assert
+		assert.Nil(t, readErr, test.encoding)
+		assert.Nil(t, closeErr, test.encoding)
+		assert.Equal(t, content, actual, test.encoding)
+		assert.True(t, body.closed, test.encoding)
+	}
+}
+
+func TestDecodeResponseBodyErrors(t *testing.T) {
+	content := []byte(strings.Repeat("a", 1024))
+
+	tests := []struct {
+		encoding      string
+		header        string
+		contentLength int64
+		maxSize       int64
+		expected      error
+	}{
+		{encoding: "identity", header: "compress", contentLength: -1, maxSize: 2048},
+		{encoding: "identity", header: "gzip", contentLength: -1, maxSize: 2048},
+		{encoding: "identity", header: "", contentLength: 1024, maxSize: 512, expected: ErrResponseTooLarge},
+		{encoding: "identity", header: "", contentLength: -1, maxSize: 512, expected: ErrResponseTooLarge},
+		{encoding: "gzip", header: "gzip", contentLength: -1, maxSize: 512, expected: ErrResponseTooLarge},
+		{encoding: "br", header: "br", contentLength: -1, maxSize: 512, expected: ErrResponseTooLarge},
+	}
+
+	for _, test := range tests {
+		// Arrange
+		response, body := newEncodedResponse(t, test.encoding, test.header, content)
+		response.ContentLength = test.contentLength
+
+		// Act
+		reader, err := decodeResponseBody(response, test.maxSize)
+		if err == nil {
+			_, err = io.ReadAll(reader)
+			reader.Close()
+		}
+
+		// Assert
+		assert.NotNil(t, err, test)
+		assert.True(t, body.closed, test)
+		if test.expected != nil {
+			assert.ErrorIs(t, err, test.expected, test)
+		}
+	}
+}
+
+func TestDoRequestStatusErrors(t *testing.T) {
+	tests := []struct {
+		status    int
+		notFound  bool
+		throttled bool
+	}{
+		{status: http.StatusNotFound, notFound: true},
+		{status: http.StatusGone, notFound: true},
+		{status: http.StatusTooManyRequests, throttled: true},
+		{status: http.StatusServiceUnavailable, throttled: true},
+		{status: http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		// Arrange
+		handler, _ := newGzipHandler(test.status)
+		server := httptest.NewServer(handler)
+		client := &InvestingHttpClient{RetryCount: 1}
+
+		// Act
+		_, err := client.doRequest(context.Background(), "GET", server.URL, nil, nil)
+		server.Close()
+
+		// Assert
+		var se *StatusError
+		assert.True(t, errors.As(err, &se))
+		assert.Equal(t, test.status, se.StatusCode)
+		assert.Equal(t, test.notFound, IsNotFoundError(fmt.Errorf("wrapped: %w", err)))
+		assert.Equal(t, test.throttled, IsThrottledError(fmt.Errorf("wrapped: %w", err)))
+	}
+}
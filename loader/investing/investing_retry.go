@@ -0,0 +1,150 @@
+package investing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMinBackoff = time.Second
+	defaultMaxBackoff = time.Minute
+)
+
+// StatusError is returned for responses with unexpected status code.
+type StatusError struct {
+	StatusCode int
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("investing client do request: invalid response code '%v'", e.StatusCode)
+}
+
+// IsThrottled reports whether source rejected request because of request rate.
+func (e *StatusError) IsThrottled() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode == http.StatusServiceUnavailable
+}
+
+// IsNotFound reports whether requested page doesn't exist on source.
+func (e *StatusError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound || e.StatusCode == http.StatusGone
+}
+
+// IsThrottledError reports whether err is caused by source throttling.
+func IsThrottledError(err error) bool {
+	var se *StatusError
+	return errors.As(err, &se) && se.IsThrottled()
+}
+
+// IsNotFoundError reports whether err is caused by page missing on source.
+func IsNotFoundError(err error) bool {
+	var se *StatusError
+	return errors.As(err, &se) && se.IsNotFound()
+}
+
+// IsSourceError reports whether err is caused by loading documents from source, not by
+// parsing them: unexpected status, network failure, canceled request or open circuit.
+func IsSourceError(err error) bool {
+	var (
+		se *StatusError
+		ne net.Error
+	)
+
+	return errors.As(err, &se) || errors.As(err, &ne) ||
+		errors.Is(err, ErrSourceUnavailable) || errors.Is(err, ErrResponseTooLarge) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func newStatusError(response *http.Response) *StatusError {
+	return &StatusError{
+		StatusCode: response.StatusCode,
+		RetryAfter: parseRetryAfter(response.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+// isRetryableError reports whether request may succeed when repeated. Throttling, timeouts,
+// server errors and network failures are retryable, other client errors are terminal.
+func isRetryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var se *StatusError
+
+	if errors.As(err, &se) {
+		switch se.StatusCode {
+		case http.StatusRequestTimeout, http.StatusTooManyRequests,
+			http.StatusInternalServerError, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	var ne net.Error
+
+	return errors.As(err, &ne)
+}
+
+// parseRetryAfter parses Retry-After header value in delay seconds or http date formats.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if len(value) == 0 {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+
+	return 0
+}
+
+// retryDelay returns exponential backoff with jitter for retry attempt (starting from 1),
+// delay requested by server with Retry-After header takes precedence when it's longer, but
+// it's limited by max backoff so server can't stall loading for arbitrary time.
+func (client *InvestingHttpClient) retryDelay(attempt int, err error) time.Duration {
+	minBackoff, maxBackoff := client.MinBackoff, client.MaxBackoff
+
+	if minBackoff <= 0 {
+		minBackoff = defaultMinBackoff
+	}
+
+	if maxBackoff < minBackoff {
+		maxBackoff = minBackoff
+	}
+
+	backoff := maxBackoff
+
+	if shift := attempt - 1; shift < 32 {
+		if d := minBackoff << shift; d > 0 && d < maxBackoff {
+			backoff = d
+		}
+	}
+
+	delay := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+
+	var se *StatusError
+
+	if errors.As(err, &se) && se.RetryAfter > delay {
+		delay = se.RetryAfter
+	}
+
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+
+	return delay
+}
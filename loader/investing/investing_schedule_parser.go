@@ -4,49 +4,139 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
 )
 
 type InvestingScheduleParser struct {
-	idRegEx     *regexp.Regexp
-	numberRegEx *regexp.Regexp
+	idRegEx          *regexp.Regexp
+	numberRegEx      *regexp.Regexp
+	revisedFromRegEx *regexp.Regexp
+	selectors        *ScheduleSelectors
 }
 
 func NewInvestingScheduleParser() *InvestingScheduleParser {
 	return &InvestingScheduleParser{
-		idRegEx:     regexp.MustCompile(`(\d+)`),
-		numberRegEx: regexp.MustCompile(`^-?\d+(\.\d+)?`),
+		idRegEx:          regexp.MustCompile(`(\d+)`),
+		numberRegEx:      regexp.MustCompile(`^(-?[\d,]*\d(?:\.\d+)?)\s*([KMBT]?)\s*([%\pL]{0,16})$`),
+		revisedFromRegEx: regexp.MustCompile(`-?[\d,]*\d(?:\.\d+)?\s*[KMBT]?\S*\s*$`),
 	}
 }
 
-func (parser *InvestingScheduleParser) ParseScheduleHtml(s *goquery.Document, languageId int) (items []*InvestingScheduleRow, err error) {
+// ParseScheduleHtml parses schedule in strict mode, document fails when any of rows is failed.
+func (parser *InvestingScheduleParser) ParseScheduleHtml(s *goquery.Document, languageId int) ([]*InvestingScheduleRow, error) {
+	result, err := parser.ParseScheduleRows(s, languageId)
+	if err != nil {
+		return nil, err
+	}
+	if err = StrictParsing.Err(len(result.Rows), result.Errors); err != nil {
+		return nil, err
+	}
+	return result.Rows, nil
+}
+
+// ParseScheduleRows parses every schedule row, rows failed to parse are reported in result errors.
+func (parser *InvestingScheduleParser) ParseScheduleRows(s *goquery.Document, languageId int) (*ScheduleParseResult, error) {
 	if s == nil {
 		return nil, fmt.Errorf("argument html value is nil")
 	}
-	tableRows := s.Find("table tr[event_attr_id]")
-	items = make([]*InvestingScheduleRow, len(tableRows.Nodes))
-	tableRows.EachWithBreak(func(i int, s *goquery.Selection) bool {
+	tableRows := s.Find(parser.getSelectors().EventRows)
+	result := ScheduleParseResult{
+		Rows: make([]*InvestingScheduleRow, 0, len(tableRows.Nodes)),
+	}
+	tableRows.Each(func(i int, s *goquery.Selection) {
 		item, err := parser.parseScheduleRowHtml(s)
 		if err != nil {
+			rowId, _ := parser.parseScheduleRowId(s)
+			result.Errors = append(result.Errors, &RowError{Index: i, RowId: rowId, Err: err})
+			return
+		}
+		item.LanguageId = languageId
+		result.Rows = append(result.Rows, item)
+	})
+	return &result, nil
+}
+
+func (parser *InvestingScheduleParser) ParseHolidaysHtml(s *goquery.Document, languageId int, date time.Time) (items []*InvestingHoliday, err error) {
+	if s == nil {
+		return nil, fmt.Errorf("argument html value is nil")
+	}
+	sel := parser.getSelectors()
+	items = make([]*InvestingHoliday, 0)
+	s.Find(sel.Rows).EachWithBreak(func(i int, s *goquery.Selection) bool {
+		if dayCell := s.Find(sel.Day); len(dayCell.Nodes) > 0 {
+			if date, err = parser.parseScheduleDay(dayCell); err != nil {
+				return false
+			}
+			return true
+		}
+		if _, ok := s.Attr(sel.EventIdAttr); ok {
+			return true
+		}
+		if id, _ := s.Attr("id"); !strings.HasPrefix(id, sel.HolidayRowPrefix) {
+			return true
+		}
+		var item *InvestingHoliday
+		if item, err = parser.parseHolidayRowHtml(s); err != nil {
 			return false
 		}
+		item.Date = date
 		item.LanguageId = languageId
-		items[i] = item
+		items = append(items, item)
 		return true
 	})
+	if err != nil {
+		return nil, err
+	}
 	return
 }
 
+func (parser *InvestingScheduleParser) parseHolidayRowHtml(s *goquery.Selection) (*InvestingHoliday, error) {
+	var err error
+	result := InvestingHoliday{}
+
+	if result.Id, err = parser.parseScheduleRowId(s); err != nil {
+		return nil, err
+	}
+	if result.CountryName, err = parser.parseScheduleCountryName(s); err != nil {
+		return nil, err
+	}
+	cell := s.Find(parser.getSelectors().HolidayTitle)
+	if len(cell.Nodes) <= 0 {
+		return nil, fmt.Errorf("invalid html. holiday title cell not found")
+	}
+	result.Title = normalizeHtmlText(cell.Text())
+
+	return &result, nil
+}
+
+func (parser *InvestingScheduleParser) parseScheduleDay(s *goquery.Selection) (time.Time, error) {
+	idVal, err := getAttrValue(s, "id")
+	if err != nil {
+		return time.Time{}, err
+	}
+	unixStr := parser.idRegEx.FindString(idVal)
+	if len(unixStr) <= 0 {
+		return time.Time{}, fmt.Errorf("day id attribute has invalid value '%s'", idVal)
+	}
+	unix, err := strconv.ParseInt(unixStr, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(unix, 0).UTC(), nil
+}
+
 func (parser *InvestingScheduleParser) parseScheduleRowHtml(s *goquery.Selection) (*InvestingScheduleRow, error) {
 	var err error
+	sel := parser.getSelectors()
 	result := InvestingScheduleRow{}
 
 	if result.Id, err = parser.parseScheduleRowId(s); err != nil {
 		return nil, err
 	}
-	if result.EventId, err = parseAttrValueToInt(s, "event_attr_id"); err != nil {
+	if result.EventId, err = parseAttrValueToInt(s, sel.EventIdAttr); err != nil {
 		return nil, err
 	}
 	if result.TimeStamp, err = parser.parseScheduleTimeStamp(s); err != nil {
@@ -64,15 +154,21 @@ func (parser *InvestingScheduleParser) parseScheduleRowHtml(s *goquery.Selection
 	if result.CountryName, err = parser.parseScheduleCountryName(s); err != nil {
 		return nil, err
 	}
-	if result.Actual, err = parser.parseIndexValue(s, "act", "actual"); err != nil {
+	if result.ActualValue, err = parser.parseIndexValue(s, sel.Actual, "actual"); err != nil {
 		return nil, err
 	}
-	if result.Forecast, err = parser.parseIndexValue(s, "fore", "forecast"); err != nil {
+	if result.ForecastValue, err = parser.parseIndexValue(s, sel.Forecast, "forecast"); err != nil {
 		return nil, err
 	}
-	if result.Previous, err = parser.parseIndexValue(s, "prev", "previous"); err != nil {
+	if result.PreviousValue, err = parser.parseIndexValue(s, sel.Previous, "previous"); err != nil {
 		return nil, err
 	}
+	if result.PreviousRevisedFrom, err = parser.parsePreviousRevision(s); err != nil {
+		return nil, err
+	}
+	result.Actual = result.ActualValue.number()
+	result.Forecast = result.ForecastValue.number()
+	result.Previous = result.PreviousValue.number()
 	if result.Type, err = parser.parseScheduleEventType(s); err != nil {
 		return nil, err
 	}
@@ -97,12 +193,12 @@ func (parser *InvestingScheduleParser) parseScheduleRowId(s *goquery.Selection)
 }
 
 func (parser *InvestingScheduleParser) parseScheduleCountryName(s *goquery.Selection) (string, error) {
-	flagCell := s.Find("span.ceFlags")
+	flagCell := s.Find(parser.getSelectors().Country)
 	return getAttrValue(flagCell, "title")
 }
 
 func (parser *InvestingScheduleParser) parseScheduleTimeStamp(s *goquery.Selection) (t time.Time, err error) {
-	timeStr, err := getAttrValue(s, "data-event-datetime")
+	timeStr, err := getAttrValue(s, parser.getSelectors().TimeStampAttr)
 	if err != nil {
 		return
 	}
@@ -116,7 +212,7 @@ func (parser *InvestingScheduleParser) parseScheduleTimeStamp(s *goquery.Selecti
 }
 
 func (parser *InvestingScheduleParser) parseScheduleTitle(s *goquery.Selection) (string, error) {
-	cell := s.Find("td.event a")
+	cell := s.Find(parser.getSelectors().Title)
 	if len(cell.Nodes) <= 0 {
 		return "", fmt.Errorf("invalid html. title cell not found")
 	}
@@ -125,7 +221,7 @@ func (parser *InvestingScheduleParser) parseScheduleTitle(s *goquery.Selection)
 }
 
 func (parser *InvestingScheduleParser) parseScheduleCurrencyCode(s *goquery.Selection) (code string, err error) {
-	cell := s.Find("td.flagCur")
+	cell := s.Find(parser.getSelectors().Currency)
 	if len(cell.Nodes) <= 0 {
 		return "", fmt.Errorf("invalid html. currency cell not found")
 	}
@@ -134,7 +230,7 @@ func (parser *InvestingScheduleParser) parseScheduleCurrencyCode(s *goquery.Sele
 }
 
 func (parser *InvestingScheduleParser) parseScheduleSentiment(s *goquery.Selection) (sentiment int, err error) {
-	items := s.Find("td.sentiment i.grayFullBullishIcon")
+	items := s.Find(parser.getSelectors().Sentiment)
 	sentiment = len(items.Nodes)
 	if sentiment <= 0 || sentiment > 3 {
 		return 0, fmt.Errorf("invalid html. sentiment has invalid value %d", sentiment)
@@ -142,31 +238,58 @@ func (parser *InvestingScheduleParser) parseScheduleSentiment(s *goquery.Selecti
 	return
 }
 
-func (parser *InvestingScheduleParser) parseIndexValue(s *goquery.Selection, className, fieldName string) (*float64, error) {
-	cell := s.Find(fmt.Sprintf("td.%[1]s, td.%[1]s span", className))
+func (parser *InvestingScheduleParser) parseIndexValue(s *goquery.Selection, selector, fieldName string) (*IndexValue, error) {
+	cell := s.Find(selector)
 	if len(cell.Nodes) <= 0 {
 		return nil, fmt.Errorf("invalid html. %s cell not found", fieldName)
 	}
-	valueStr := normalizeHtmlText(cell.Text())
+	return parser.parseIndexValueString(normalizeHtmlText(cell.Text()), fieldName)
+}
+
+func (parser *InvestingScheduleParser) parseIndexValueString(valueStr, fieldName string) (*IndexValue, error) {
 	if len(valueStr) <= 0 {
 		return nil, nil
 	}
-	valueStr = parser.numberRegEx.FindString(valueStr)
-	number, err := strconv.ParseFloat(valueStr, 64)
-
+	match := parser.numberRegEx.FindStringSubmatch(valueStr)
+	if match == nil {
+		return nil, fmt.Errorf("invalid html. %s has invalid value '%s'", fieldName, valueStr)
+	}
+	number, err := strconv.ParseFloat(strings.ReplaceAll(match[1], ",", ""), 64)
 	if err != nil {
 		return nil, err
 	}
+	return &IndexValue{
+		Raw:    valueStr,
+		Number: number,
+		Scale:  match[2],
+		Unit:   match[3],
+	}, nil
+}
 
-	return &number, err
+// parsePreviousRevision returns originally reported previous value when the cell
+// carries revision marker, e.g. <span title="Revised from 0.3%">0.5%</span>. Titles
+// without value aren't revision markers, so previous value is treated as not revised.
+// Revised value failed to parse is row error, so it's handled by parse mode and quarantine.
+func (parser *InvestingScheduleParser) parsePreviousRevision(s *goquery.Selection) (*IndexValue, error) {
+	marker := s.Find(parser.getSelectors().PreviousRevision)
+	if len(marker.Nodes) <= 0 {
+		return nil, nil
+	}
+	title := normalizeHtmlText(marker.AttrOr("title", ""))
+	valueStr := strings.TrimSpace(parser.revisedFromRegEx.FindString(title))
+	if len(valueStr) <= 0 {
+		return nil, nil
+	}
+	return parser.parseIndexValueString(valueStr, "revised previous")
 }
 
 func (parser *InvestingScheduleParser) parseScheduleEventType(s *goquery.Selection) (eventType ScheduleEventType, err error) {
-	tag := s.Find("td.event span")
+	sel := parser.getSelectors()
+	tag := s.Find(sel.EventType)
 	if len(tag.Nodes) <= 0 {
 		return Index, nil
 	}
-	typeStr, err := getAttrValue(tag, "data-img_key")
+	typeStr, err := getAttrValue(tag, sel.EventTypeAttr)
 	if err != nil {
 		return
 	}
@@ -182,3 +305,11 @@ func (parser *InvestingScheduleParser) parseScheduleEventType(s *goquery.Selecti
 	}
 	return Index, fmt.Errorf("invalid html. unknown event type %s", typeStr)
 }
+
+// getSelectors returns parser selectors, default profile selectors are used when not set.
+func (parser *InvestingScheduleParser) getSelectors() *ScheduleSelectors {
+	if parser.selectors != nil {
+		return parser.selectors
+	}
+	return &defaultSelectorProfile.Schedule
+}
@@ -1,6 +1,7 @@
 package investing
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -45,7 +46,18 @@ func Test_InvestingScheduleParser_ParseScheduleRowHtml(t *testing.T) {
 				Actual:       &[]float64{5.8}[0],
 				Forecast:     nil,
 				Previous:     &[]float64{-20.5}[0],
-				Type:         Index,
+				ActualValue: &IndexValue{
+					Raw:    "5.8%",
+					Number: 5.8,
+					Unit:   "%",
+				},
+				ForecastValue: nil,
+				PreviousValue: &IndexValue{
+					Raw:    "-20.5%",
+					Number: -20.5,
+					Unit:   "%",
+				},
+				Type: Index,
 			},
 			err: nil,
 		},
@@ -256,3 +268,192 @@ func Test_InvestingScheduleParser_ParseScheduleEventType(t *testing.T) {
 		assert.Equal(t, test.expectedResult, actualResult)
 	}
 }
+
+func Test_InvestingScheduleParser_ParseHolidaysHtml(t *testing.T) {
+	// Arrange
+	html := `<table>
+				<tr><td colspan="9" class="theDay" id="theDay1632096000">Monday, September 20, 2021</td></tr>
+				<tr id="eventRowId_372">
+					<td class="first left">All Day</td>
+					<td class="flagCur left"><span title="South Korea" class="ceFlags South_Korea float_lang_base_1" data-img_key="South_Korea">&nbsp;</span></td>
+					<td class="left textNum sentiment"><span class="bold">Holiday</span></td>
+					<td colspan="6" class="left event">South Korea - Chuseok - Thanksgiving Day</td>
+				</tr>
+				<tr id="eventRowId_436019" class="js-event-item" event_attr_id="739" data-event-datetime="2021/09/20 02:00:00">
+					<td class="left flagCur noWrap"><span title="Germany" class="ceFlags Germany" data-img_key="Germany">&nbsp;</span> EUR</td>
+					<td class="left event"><a href="/economic-calendar/german-ppi-739" target="_blank">German PPI (YoY)  (Aug)</a></td>
+				</tr>
+				<tr><td colspan="9" class="theDay" id="theDay1632182400">Tuesday, September 21, 2021</td></tr>
+				<tr id="eventRowId_375">
+					<td class="first left">All Day</td>
+					<td class="flagCur left"><span title="China" class="ceFlags China float_lang_base_1" data-img_key="China">&nbsp;</span></td>
+					<td class="left textNum sentiment"><span class="bold">Holiday</span></td>
+					<td colspan="6" class="left event">China - Mid-Autumn Festival</td>
+				</tr>
+			</table>`
+	doc, _ := goquery.NewDocumentFromReader(strings.NewReader(html))
+	parser := NewInvestingScheduleParser()
+	languageId := 1
+
+	// Act
+	actualResult, err := parser.ParseHolidaysHtml(doc, languageId, time.Time{})
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, []*InvestingHoliday{
+		{
+			Id:          372,
+			LanguageId:  languageId,
+			CountryName: "South Korea",
+			Date:        time.Date(2021, time.September, 20, 0, 0, 0, 0, time.UTC),
+			Title:       "South Korea - Chuseok - Thanksgiving Day",
+		},
+		{
+			Id:          375,
+			LanguageId:  languageId,
+			CountryName: "China",
+			Date:        time.Date(2021, time.September, 21, 0, 0, 0, 0, time.UTC),
+			Title:       "China - Mid-Autumn Festival",
+		},
+	}, actualResult)
+}
+
+func Test_InvestingScheduleParser_ParseIndexValue(t *testing.T) {
+	tests := []struct {
+		html               string
+		expectedResult     *IndexValue
+		expectedNormalized float64
+		err                error
+	}{
+		{
+			html:               `<tr><td class="act">11.4%</td></tr>`,
+			expectedResult:     &IndexValue{Raw: "11.4%", Number: 11.4, Unit: "%"},
+			expectedNormalized: 11.4,
+		},
+		{
+			html:               `<tr><td class="act">1.25K</td></tr>`,
+			expectedResult:     &IndexValue{Raw: "1.25K", Number: 1.25, Scale: "K"},
+			expectedNormalized: 1250,
+		},
+		{
+			html:               `<tr><td class="act"><span title="">-3.2B</span></td></tr>`,
+			expectedResult:     &IndexValue{Raw: "-3.2B", Number: -3.2, Scale: "B"},
+			expectedNormalized: -3.2e9,
+		},
+		{
+			html:               `<tr><td class="act">250.5M</td></tr>`,
+			expectedResult:     &IndexValue{Raw: "250.5M", Number: 250.5, Scale: "M"},
+			expectedNormalized: 250.5e6,
+		},
+		{
+			html:               `<tr><td class="act">1,234.5K</td></tr>`,
+			expectedResult:     &IndexValue{Raw: "1,234.5K", Number: 1234.5, Scale: "K"},
+			expectedNormalized: 1234500,
+		},
+		{
+			html:           `<tr><td class="act">&nbsp;</td></tr>`,
+			expectedResult: nil,
+		},
+		{
+			html:           `<tr><td class="act">n/a</td></tr>`,
+			expectedResult: nil,
+			err:            fmt.Errorf("invalid html. actual has invalid value 'n/a'"),
+		},
+		{
+			html:           `<tr><td class="act">1.5 (seasonally adjusted)</td></tr>`,
+			expectedResult: nil,
+			err:            fmt.Errorf("invalid html. actual has invalid value '1.5 (seasonally adjusted)'"),
+		},
+	}
+
+	for _, test := range tests {
+		// Arrange
+		node, _ := html.Parse(strings.NewReader("<table>" + test.html + "</table>"))
+		selector := goquery.NewDocumentFromNode(node).Find("tr")
+
+		// Act
+		parser := NewInvestingScheduleParser()
+		actualResult, err := parser.parseIndexValue(selector, "td.act", "actual")
+
+		// Assert
+		assert.Equal(t, test.err, err)
+		assert.Equal(t, test.expectedResult, actualResult)
+		if actualResult != nil {
+			assert.InDelta(t, test.expectedNormalized, actualResult.Normalized(), 1e-6)
+		}
+	}
+}
+
+func Test_InvestingScheduleParser_ParsePreviousRevision(t *testing.T) {
+	tests := []struct {
+		html           string
+		expectedResult *IndexValue
+		err            error
+	}{
+		{
+			html:           `<tr><td class="prev"><span title="Revised from 0.3%">0.5%</span></td></tr>`,
+			expectedResult: &IndexValue{Raw: "0.3%", Number: 0.3, Unit: "%"},
+		},
+		{
+			html:           `<tr><td class="prev"><span title="Пересмотрено с -1.2K">-1.0K</span></td></tr>`,
+			expectedResult: &IndexValue{Raw: "-1.2K", Number: -1.2, Scale: "K"},
+		},
+		{
+			html:           `<tr><td class="prev"><span title="">0.5%</span></td></tr>`,
+			expectedResult: nil,
+		},
+		{
+			html:           `<tr><td class="prev">0.5%</td></tr>`,
+			expectedResult: nil,
+		},
+		{
+			html:           `<tr><td class="prev"><span title="Revised">0.5%</span></td></tr>`,
+			expectedResult: nil,
+		},
+		{
+			html:           `<tr><td class="prev"><span title="Preliminary release, see details">0.5%</span></td></tr>`,
+			expectedResult: nil,
+		},
+		{
+			html:           `<tr><td class="prev"><span title="Revised from 0.3$">0.5%</span></td></tr>`,
+			expectedResult: nil,
+			err:            fmt.Errorf("invalid html. revised previous has invalid value '0.3$'"),
+		},
+	}
+
+	for _, test := range tests {
+		// Arrange
+		node, _ := html.Parse(strings.NewReader("<table>" + test.html + "</table>"))
+		selector := goquery.NewDocumentFromNode(node).Find("tr")
+
+		// Act
+		parser := NewInvestingScheduleParser()
+		actualResult, err := parser.parsePreviousRevision(selector)
+
+		// Assert
+		assert.Equal(t, test.err, err)
+		assert.Equal(t, test.expectedResult, actualResult)
+	}
+}
+
+func Test_InvestingScheduleParser_ParseScheduleRows(t *testing.T) {
+	// Arrange
+	doc, _ := (&InvestingHtmlSourceMock{}).LoadEventsScheduleHtml(context.Background(), time.Now(), time.Now(), 1)
+	doc.Find("#eventRowId_436019 i.grayFullBullishIcon").Remove()
+	parser := NewInvestingScheduleParser()
+
+	// Act
+	result, err := parser.ParseScheduleRows(doc, 1)
+	rows, strictErr := parser.ParseScheduleHtml(doc, 1)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Len(t, result.Rows, 1)
+	assert.Equal(t, 437026, result.Rows[0].Id)
+	assert.Len(t, result.Errors, 1)
+	assert.Equal(t, 0, result.Errors[0].Index)
+	assert.Equal(t, 436019, result.Errors[0].RowId)
+	assert.EqualError(t, result.Errors[0].Err, "invalid html. sentiment has invalid value 0")
+	assert.Nil(t, rows)
+	assert.Equal(t, result.Errors, strictErr)
+}
@@ -15,18 +15,25 @@ const (
 )
 
 type InvestingScheduleRow struct {
-	EventId      int
-	Id           int
-	LanguageId   int
-	CountryName  string
-	Title        string
-	TimeStamp    time.Time
-	CurrencyCode string
-	Sentiment    int
-	Actual       *float64
-	Forecast     *float64
-	Previous     *float64
-	Type         ScheduleEventType
+	EventId       int
+	Id            int
+	LanguageId    int
+	CountryName   string
+	Title         string
+	TimeStamp     time.Time
+	CurrencyCode  string
+	Sentiment     int
+	Actual        *float64
+	Forecast      *float64
+	Previous      *float64
+	ActualValue   *IndexValue
+	ForecastValue *IndexValue
+	PreviousValue *IndexValue
+	Type          ScheduleEventType
+
+	// PreviousRevisedFrom holds originally reported previous value when investing
+	// marks the previous figure as revised.
+	PreviousRevisedFrom *IndexValue
 }
 
 func (r *InvestingScheduleRow) GetId() int {
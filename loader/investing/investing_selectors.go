@@ -0,0 +1,340 @@
+package investing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultSelectorProfileVersion = "builtin-2021.09"
+
+// defaultSelectorProfile is used by parsers created without profile.
+var defaultSelectorProfile = DefaultSelectorProfile()
+
+// SelectorProfile is versioned set of CSS selectors and attribute names used by parsers.
+// Profile file may define only changed selectors, the rest are taken from default profile.
+type SelectorProfile struct {
+	Version   string            `json:"version" yaml:"version"`
+	Schedule  ScheduleSelectors `json:"schedule" yaml:"schedule"`
+	Event     EventSelectors    `json:"event" yaml:"event"`
+	Countries CountrySelectors  `json:"countries" yaml:"countries"`
+}
+
+type ScheduleSelectors struct {
+	Rows             string `json:"rows" yaml:"rows"`
+	EventRows        string `json:"eventRows" yaml:"eventRows"`
+	Day              string `json:"day" yaml:"day"`
+	HolidayTitle     string `json:"holidayTitle" yaml:"holidayTitle"`
+	Title            string `json:"title" yaml:"title"`
+	Currency         string `json:"currency" yaml:"currency"`
+	Sentiment        string `json:"sentiment" yaml:"sentiment"`
+	Country          string `json:"country" yaml:"country"`
+	Actual           string `json:"actual" yaml:"actual"`
+	Forecast         string `json:"forecast" yaml:"forecast"`
+	Previous         string `json:"previous" yaml:"previous"`
+	PreviousRevision string `json:"previousRevision" yaml:"previousRevision"`
+	EventType        string `json:"eventType" yaml:"eventType"`
+
+	EventIdAttr      string `json:"eventIdAttr" yaml:"eventIdAttr"`
+	TimeStampAttr    string `json:"timeStampAttr" yaml:"timeStampAttr"`
+	EventTypeAttr    string `json:"eventTypeAttr" yaml:"eventTypeAttr"`
+	HolidayRowPrefix string `json:"holidayRowPrefix" yaml:"holidayRowPrefix"`
+}
+
+type EventSelectors struct {
+	Section   string `json:"section" yaml:"section"`
+	Title     string `json:"title" yaml:"title"`
+	Overview  string `json:"overview" yaml:"overview"`
+	Source    string `json:"source" yaml:"source"`
+	Unit      string `json:"unit" yaml:"unit"`
+	Sentiment string `json:"sentiment" yaml:"sentiment"`
+	Country   string `json:"country" yaml:"country"`
+}
+
+type CountrySelectors struct {
+	Rows  string `json:"rows" yaml:"rows"`
+	Id    string `json:"id" yaml:"id"`
+	Title string `json:"title" yaml:"title"`
+}
+
+// DefaultSelectorProfile returns selectors matching investing.com markup parsers were written for.
+func DefaultSelectorProfile() *SelectorProfile {
+	return &SelectorProfile{
+		Version: defaultSelectorProfileVersion,
+		Schedule: ScheduleSelectors{
+			Rows:             "table tr",
+			EventRows:        "table tr[event_attr_id]",
+			Day:              "td.theDay",
+			HolidayTitle:     "td.event",
+			Title:            "td.event a",
+			Currency:         "td.flagCur",
+			Sentiment:        "td.sentiment i.grayFullBullishIcon",
+			Country:          "span.ceFlags",
+			Actual:           "td.act",
+			Forecast:         "td.fore",
+			Previous:         "td.prev",
+			PreviousRevision: "td.prev span[title]",
+			EventType:        "td.event span",
+			EventIdAttr:      "event_attr_id",
+			TimeStampAttr:    "data-event-datetime",
+			EventTypeAttr:    "data-img_key",
+			HolidayRowPrefix: "eventRowId_",
+		},
+		Event: EventSelectors{
+			Section:   "#leftColumn",
+			Title:     "h1.ecTitle",
+			Overview:  "#overViewBox div.left",
+			Source:    "div.right div:last-child a",
+			Unit:      "#releaseInfo div.arial_14",
+			Sentiment: "i.grayFullBullishIcon",
+			Country:   "i.ceFlags",
+		},
+		Countries: CountrySelectors{
+			Rows:  "#filtersWrapper ul.countryOption li",
+			Id:    "input",
+			Title: "label",
+		},
+	}
+}
+
+// LoadSelectorProfile reads selector profile from YAML or JSON file and validates it against
+// fixture documents directory (recorded documents layout). Default profile is returned
+// when file name is empty.
+func LoadSelectorProfile(fileName, fixturesDir string) (*SelectorProfile, error) {
+	profile := DefaultSelectorProfile()
+
+	if len(fileName) > 0 {
+		content, err := os.ReadFile(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("read selector profile '%s' error: %w", fileName, err)
+		}
+
+		switch strings.ToLower(filepath.Ext(fileName)) {
+		case ".yaml", ".yml":
+			err = yaml.Unmarshal(content, profile)
+		default:
+			err = json.Unmarshal(content, profile)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse selector profile '%s' error: %w", fileName, err)
+		}
+	}
+
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+
+	if len(fixturesDir) == 0 {
+		return profile, nil
+	}
+
+	if err := profile.ValidateFixtures(fixturesDir); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// Validate checks that every selector and attribute name is set and selectors are valid CSS.
+func (p *SelectorProfile) Validate() error {
+	if len(p.Version) == 0 {
+		return fmt.Errorf("selector profile version is empty")
+	}
+
+	sections := []struct {
+		name       string
+		selectors  map[string]string
+		attributes map[string]string
+	}{
+		{name: "schedule", selectors: p.Schedule.selectors(), attributes: p.Schedule.attributes()},
+		{name: "event", selectors: p.Event.selectors()},
+		{name: "countries", selectors: p.Countries.selectors()},
+	}
+
+	for _, section := range sections {
+		for _, name := range sortedKeys(section.attributes) {
+			if len(section.attributes[name]) == 0 {
+				return fmt.Errorf("selector profile '%s': %s.%s is empty", p.Version, section.name, name)
+			}
+		}
+
+		for _, name := range sortedKeys(section.selectors) {
+			selector := section.selectors[name]
+			if len(selector) == 0 {
+				return fmt.Errorf("selector profile '%s': %s.%s is empty", p.Version, section.name, name)
+			}
+			if _, err := cascadia.Compile(selector); err != nil {
+				return fmt.Errorf("selector profile '%s': %s.%s '%s' is invalid: %w", p.Version, section.name, name, selector, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateFixtures parses every fixture document with profile selectors, fixture documents
+// must be parsed without errors and contain at least one item.
+func (p *SelectorProfile) ValidateFixtures(directory string) error {
+	fixtures := 0
+
+	err := filepath.WalkDir(directory, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".html" {
+			return err
+		}
+
+		name := filepath.Base(path)
+		var kind DocumentKind
+
+		switch {
+		case strings.HasPrefix(name, "schedule_"):
+			kind = ScheduleDocument
+		case strings.HasPrefix(name, "event_"):
+			kind = EventDetailsDocument
+		case name == "countries.html":
+			kind = CountriesDocument
+		default:
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		html, err := goquery.NewDocumentFromReader(file)
+		if err != nil {
+			return err
+		}
+
+		if err = p.validateFixture(kind, html); err != nil {
+			return fmt.Errorf("selector profile '%s' doesn't match fixture '%s': %w", p.Version, path, err)
+		}
+
+		fixtures++
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("validate selector profile fixtures error: %w", err)
+	}
+
+	if fixtures == 0 {
+		return fmt.Errorf("validate selector profile fixtures error: no fixtures found in '%s'", directory)
+	}
+
+	return nil
+}
+
+func (p *SelectorProfile) validateFixture(kind DocumentKind, html *goquery.Document) error {
+	switch kind {
+	case ScheduleDocument:
+		rows, err := p.ScheduleParser().ParseScheduleHtml(html, 0)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return fmt.Errorf("no schedule rows found")
+		}
+	case EventDetailsDocument:
+		_, err := p.EventParser().ParseCalendarEventHtml(html)
+		return err
+	case CountriesDocument:
+		countries, err := p.CountryParser().ParseCountriesHtml(html)
+		if err != nil {
+			return err
+		}
+		if len(countries) == 0 {
+			return fmt.Errorf("no countries found")
+		}
+	}
+	return nil
+}
+
+// ScheduleParser returns schedule parser using profile selectors, nil profile uses default one.
+func (p *SelectorProfile) ScheduleParser() *InvestingScheduleParser {
+	parser := NewInvestingScheduleParser()
+	if p != nil {
+		parser.selectors = &p.Schedule
+	}
+	return parser
+}
+
+func (p *SelectorProfile) EventParser() *InvestingCalendarEventParser {
+	parser := NewInvestingCalendarEventParser()
+	if p != nil {
+		parser.selectors = &p.Event
+	}
+	return parser
+}
+
+func (p *SelectorProfile) CountryParser() *InvestingCountryParser {
+	parser := &InvestingCountryParser{}
+	if p != nil {
+		parser.selectors = &p.Countries
+	}
+	return parser
+}
+
+func (s *ScheduleSelectors) selectors() map[string]string {
+	return map[string]string{
+		"rows":             s.Rows,
+		"eventRows":        s.EventRows,
+		"day":              s.Day,
+		"holidayTitle":     s.HolidayTitle,
+		"title":            s.Title,
+		"currency":         s.Currency,
+		"sentiment":        s.Sentiment,
+		"country":          s.Country,
+		"actual":           s.Actual,
+		"forecast":         s.Forecast,
+		"previous":         s.Previous,
+		"previousRevision": s.PreviousRevision,
+		"eventType":        s.EventType,
+	}
+}
+
+func (s *ScheduleSelectors) attributes() map[string]string {
+	return map[string]string{
+		"eventIdAttr":      s.EventIdAttr,
+		"timeStampAttr":    s.TimeStampAttr,
+		"eventTypeAttr":    s.EventTypeAttr,
+		"holidayRowPrefix": s.HolidayRowPrefix,
+	}
+}
+
+func (s *EventSelectors) selectors() map[string]string {
+	return map[string]string{
+		"section":   s.Section,
+		"title":     s.Title,
+		"overview":  s.Overview,
+		"source":    s.Source,
+		"unit":      s.Unit,
+		"sentiment": s.Sentiment,
+		"country":   s.Country,
+	}
+}
+
+func (s *CountrySelectors) selectors() map[string]string {
+	return map[string]string{
+		"rows":  s.Rows,
+		"id":    s.Id,
+		"title": s.Title,
+	}
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
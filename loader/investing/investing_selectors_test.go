@@ -0,0 +1,136 @@
+package investing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSelectorFixtures(t *testing.T) string {
+	ctx := context.Background()
+	directory := t.TempDir()
+	recorder := NewInvestingRecordingSource(&InvestingHtmlSourceMock{}, directory)
+	date := time.Date(2021, time.September, 20, 0, 0, 0, 0, time.UTC)
+
+	_, err := recorder.LoadEventsScheduleHtml(ctx, date, date, 1)
+	require.Nil(t, err)
+	_, err = recorder.LoadEventDetailsHtml(ctx, 739, 1)
+	require.Nil(t, err)
+	_, err = recorder.LoadCountriesHtml(ctx, 1)
+	require.Nil(t, err)
+
+	return directory
+}
+
+func writeSelectorProfile(t *testing.T, name, content string) string {
+	fileName := filepath.Join(t.TempDir(), name)
+	require.Nil(t, os.WriteFile(fileName, []byte(content), 0644))
+	return fileName
+}
+
+func TestLoadSelectorProfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+		err      string
+	}{
+		{
+			name:     "profile.yaml",
+			content:  "version: v2\ncountries:\n  rows: '#filtersWrapper ul.countryList li'\n",
+			expected: "#filtersWrapper ul.countryList li",
+		},
+		{
+			name:     "profile.json",
+			content:  `{"version": "v2", "countries": {"rows": "#filtersWrapper ul.countryList li"}}`,
+			expected: "#filtersWrapper ul.countryList li",
+		},
+		{
+			name:    "invalid.json",
+			content: `{"version": "v2", "countries": {"rows": "ul[li"}}`,
+			err:     "selector profile 'v2': countries.rows 'ul[li' is invalid",
+		},
+		{
+			name:    "empty.yaml",
+			content: "version: v2\nschedule:\n  eventIdAttr: ''\n",
+			err:     "selector profile 'v2': schedule.eventIdAttr is empty",
+		},
+	}
+
+	for _, test := range tests {
+		// Arrange
+		fileName := writeSelectorProfile(t, test.name, test.content)
+
+		// Act
+		profile, err := LoadSelectorProfile(fileName, "")
+
+		// Assert
+		if len(test.err) > 0 {
+			require.NotNil(t, err, test.name)
+			assert.Contains(t, err.Error(), test.err, test.name)
+			continue
+		}
+
+		require.Nil(t, err, test.name)
+		assert.Equal(t, "v2", profile.Version, test.name)
+		assert.Equal(t, test.expected, profile.Countries.Rows, test.name)
+		assert.Equal(t, DefaultSelectorProfile().Schedule, profile.Schedule, test.name)
+	}
+}
+
+func TestLoadSelectorProfile_Fixtures(t *testing.T) {
+	// Arrange
+	fixtures := writeSelectorFixtures(t)
+	changed := writeSelectorProfile(t, "changed.yaml", "version: v2\ncountries:\n  rows: '#filtersWrapper ul.countryList li'\n")
+
+	// Act
+	profile, err := LoadSelectorProfile("", fixtures)
+	_, changedErr := LoadSelectorProfile(changed, fixtures)
+	_, emptyErr := LoadSelectorProfile("", t.TempDir())
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, defaultSelectorProfileVersion, profile.Version)
+	require.NotNil(t, changedErr)
+	assert.Contains(t, changedErr.Error(), "selector profile 'v2' doesn't match fixture")
+	assert.Contains(t, changedErr.Error(), "countries.html")
+	assert.NotNil(t, emptyErr)
+}
+
+func TestSelectorProfile_Parsers(t *testing.T) {
+	// Arrange
+	profile := DefaultSelectorProfile()
+	profile.Countries.Rows = "#filtersWrapper ul.countryList li"
+	profile.Schedule.Title = "td.event span.title"
+	profile.Schedule.EventType = "td.event span[data-img_key]"
+	countriesHtml, _ := goquery.NewDocumentFromReader(strings.NewReader(`
+		<div id="filtersWrapper">
+			<ul class="countryList">
+				<li><input value="1"><label>Text 1</label></li>
+			</ul>
+		</div>`))
+	scheduleHtml, _ := (&InvestingHtmlSourceMock{}).LoadEventsScheduleHtml(context.Background(), time.Now(), time.Now(), 1)
+	scheduleHtml.Find("td.event a").Each(func(i int, s *goquery.Selection) {
+		s.ReplaceWithHtml(`<span class="title">` + s.Text() + `</span>`)
+	})
+
+	// Act
+	countries, countriesErr := profile.CountryParser().ParseCountriesHtml(countriesHtml)
+	rows, scheduleErr := profile.ScheduleParser().ParseScheduleHtml(scheduleHtml, 1)
+	_, defaultErr := (*SelectorProfile)(nil).ScheduleParser().ParseScheduleHtml(scheduleHtml, 1)
+
+	// Assert
+	assert.Nil(t, countriesErr)
+	assert.Equal(t, []*InvestingCountry{{Id: 1, Title: "Text 1"}}, countries)
+	assert.Nil(t, scheduleErr)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "German PPI (YoY)  (Aug)", rows[0].Title)
+	assert.NotNil(t, defaultErr)
+}
@@ -0,0 +1,333 @@
+package investing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// defaultUserAgents is used when user-agent pool is not configured.
+var defaultUserAgents = []string{
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:109.0) Gecko/20100101 Firefox/114.0",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.5 Safari/605.1.15",
+	"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Safari/537.36",
+}
+
+type proxyContextKey struct{}
+
+// newHttpClient creates http client sending requests through proxy chosen for
+// request by doRequest, proxy is passed to transport with request context.
+func newHttpClient(timeout time.Duration, jar http.CookieJar) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		if proxy, ok := req.Context().Value(proxyContextKey{}).(*proxyState); ok {
+			return proxy.url, nil
+		}
+		return nil, nil
+	}
+
+	if timeout > 0 {
+		transport.DialContext = (&net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}).DialContext
+		transport.TLSHandshakeTimeout = timeout
+		transport.ResponseHeaderTimeout = timeout
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+		Jar:       jar,
+	}
+}
+
+// splitList splits pool setting separated with '|' skipping empty items, pools use the same
+// separator as user-agents contain commas.
+func splitList(value string) []string {
+	items := make([]string, 0)
+
+	for _, item := range strings.Split(value, "|") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// parseUserAgents splits user-agent pool separated with '|'.
+func parseUserAgents(value string) []string {
+	userAgents := splitList(value)
+
+	if len(userAgents) == 0 {
+		return defaultUserAgents
+	}
+
+	return userAgents
+}
+
+func randomUserAgent(userAgents []string) string {
+	if len(userAgents) == 0 {
+		userAgents = defaultUserAgents
+	}
+	return userAgents[rand.Intn(len(userAgents))]
+}
+
+type proxyState struct {
+	url       *url.URL
+	failures  int
+	downUntil time.Time
+}
+
+// proxyPool rotates requests over proxies in round robin order. Proxy failed with
+// network error is skipped for cooldown period doubled with every next failure.
+type proxyPool struct {
+	cooldown time.Duration
+	now      func() time.Time
+	mu       sync.Mutex
+	proxies  []*proxyState
+	next     int
+}
+
+func newProxyPool(proxies []string, cooldown time.Duration) (*proxyPool, error) {
+	pool := proxyPool{cooldown: cooldown, now: time.Now}
+
+	for _, proxy := range proxies {
+		if proxy = strings.TrimSpace(proxy); len(proxy) == 0 {
+			continue
+		}
+
+		proxyUrl, err := url.Parse(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("parse proxy url '%s' error: %w", proxy, err)
+		}
+
+		switch proxyUrl.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("proxy url '%s' has unsupported scheme '%s'", proxy, proxyUrl.Scheme)
+		}
+
+		pool.proxies = append(pool.proxies, &proxyState{url: proxyUrl})
+	}
+
+	if len(pool.proxies) == 0 {
+		return nil, nil
+	}
+
+	return &pool, nil
+}
+
+// Next returns healthy proxy for request, when all proxies are down the one
+// recovering first is returned. Nil pool sends requests directly.
+func (p *proxyPool) Next() *proxyState {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	var recovering *proxyState
+
+	for i := 0; i < len(p.proxies); i++ {
+		proxy := p.proxies[(p.next+i)%len(p.proxies)]
+
+		if !now.Before(proxy.downUntil) {
+			p.next = (p.next + i + 1) % len(p.proxies)
+			return proxy
+		}
+
+		if recovering == nil || proxy.downUntil.Before(recovering.downUntil) {
+			recovering = proxy
+		}
+	}
+
+	return recovering
+}
+
+// Report updates proxy health with request result. Only network errors mark proxy
+// as failed, response status errors are caused by source.
+func (p *proxyPool) Report(ctx context.Context, proxy *proxyState, err error) {
+	if p == nil || proxy == nil {
+		return
+	}
+
+	// canceled or expired request says nothing about proxy health
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var netErr net.Error
+
+	if err == nil || !errors.As(err, &netErr) {
+		proxy.failures = 0
+		proxy.downUntil = time.Time{}
+		return
+	}
+
+	proxy.failures++
+	shift := proxy.failures - 1
+
+	if shift > 5 {
+		shift = 5
+	}
+
+	proxy.downUntil = p.now().Add(p.cooldown << shift)
+}
+
+// persistentCookieJar is cookie jar saving cookies set by source into file, so
+// loader restarts keep source session.
+type persistentCookieJar struct {
+	jar      *cookiejar.Jar
+	fileName string
+	mu       sync.Mutex
+	cookies  map[string]map[string]*http.Cookie
+}
+
+func newCookieJar(fileName string) (http.CookieJar, error) {
+	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+	if err != nil {
+		return nil, fmt.Errorf("create cookie jar error: %w", err)
+	}
+
+	if len(fileName) == 0 {
+		return jar, nil
+	}
+
+	pj := persistentCookieJar{
+		jar:      jar,
+		fileName: fileName,
+		cookies:  make(map[string]map[string]*http.Cookie),
+	}
+
+	if err = pj.load(); err != nil {
+		return nil, err
+	}
+
+	return &pj, nil
+}
+
+func (j *persistentCookieJar) Cookies(u *url.URL) []*http.Cookie {
+	return j.jar.Cookies(u)
+}
+
+func (j *persistentCookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.jar.SetCookies(u, cookies)
+
+	if len(cookies) == 0 {
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	key := (&url.URL{Scheme: u.Scheme, Host: u.Host}).String()
+	stored, ok := j.cookies[key]
+
+	if !ok {
+		stored = make(map[string]*http.Cookie)
+		j.cookies[key] = stored
+	}
+
+	now := time.Now()
+
+	for _, cookie := range cookies {
+		if cookie.MaxAge < 0 {
+			delete(stored, cookie.Name)
+			continue
+		}
+		// max age is relative to response time, stored cookie keeps absolute expiration
+		if cookie.MaxAge > 0 {
+			expires := *cookie
+			expires.Expires = now.Add(time.Duration(cookie.MaxAge) * time.Second)
+			expires.MaxAge = 0
+			cookie = &expires
+		}
+		stored[cookie.Name] = cookie
+	}
+
+	// cookie jar interface doesn't return errors, failed save keeps cookies in memory
+	_ = j.save()
+}
+
+func (j *persistentCookieJar) load() error {
+	content, err := os.ReadFile(j.fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read cookie file '%s' error: %w", j.fileName, err)
+	}
+
+	stored := make(map[string][]*http.Cookie)
+
+	if err = json.Unmarshal(content, &stored); err != nil {
+		return fmt.Errorf("parse cookie file '%s' error: %w", j.fileName, err)
+	}
+
+	now := time.Now()
+
+	for key, cookies := range stored {
+		u, err := url.Parse(key)
+		if err != nil {
+			return fmt.Errorf("parse cookie file '%s' url '%s' error: %w", j.fileName, key, err)
+		}
+
+		valid := make(map[string]*http.Cookie)
+
+		for _, cookie := range cookies {
+			if !cookie.Expires.IsZero() && cookie.Expires.Before(now) {
+				continue
+			}
+			valid[cookie.Name] = cookie
+		}
+
+		j.cookies[key] = valid
+
+		for _, cookie := range valid {
+			j.jar.SetCookies(u, []*http.Cookie{cookie})
+		}
+	}
+
+	return nil
+}
+
+func (j *persistentCookieJar) save() error {
+	stored := make(map[string][]*http.Cookie, len(j.cookies))
+
+	for key, cookies := range j.cookies {
+		for _, cookie := range cookies {
+			stored[key] = append(stored[key], cookie)
+		}
+	}
+
+	content, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	tmpName := j.fileName + ".tmp"
+
+	if err = os.WriteFile(tmpName, content, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpName, j.fileName)
+}
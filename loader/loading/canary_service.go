@@ -0,0 +1,399 @@
+package loading
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/denis-gudim/economic-calendar/loader"
+	"github.com/denis-gudim/economic-calendar/loader/investing"
+	"github.com/denis-gudim/economic-calendar/loader/metrics"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	canaryScheduleRows     = "schedule_rows"
+	defaultCanaryTolerance = 0.1
+	canaryResultOk         = "ok"
+	canaryResultDrift      = "drift"
+	canaryResultError      = "error"
+	canaryResultSource     = "source_error"
+	canaryResultSkipped    = "skipped"
+	canaryMinScheduleRate  = 0.9
+)
+
+// canaryBaselineMinimums are absolute values current stats have to reach before they are
+// stored as the first baseline, so broken parser output doesn't become expected one.
+var canaryBaselineMinimums = CanaryStats{
+	canaryScheduleRows:            1,
+	"schedule_parsed_rate":        canaryMinScheduleRate,
+	"schedule_title_coverage":     canaryMinScheduleRate,
+	"schedule_sentiment_coverage": canaryMinScheduleRate,
+	"schedule_country_coverage":   canaryMinScheduleRate,
+	"event_title_present":         1,
+	"event_sentiment_valid":       1,
+	"event_country_present":       1,
+}
+
+// CanaryStats are field coverage rates of canary documents keyed by metric name. Rates are
+// shares in [0, 1] range except schedule rows count.
+type CanaryStats map[string]float64
+
+type CanaryDrift struct {
+	Metric   string  `json:"metric"`
+	Baseline float64 `json:"baseline"`
+	Actual   float64 `json:"actual"`
+}
+
+// CanaryReport is canary check result. Error is set when documents failed to parse or check
+// couldn't be done, SourceError when documents failed to load, the latter says nothing about
+// parsers and doesn't make report unhealthy.
+type CanaryReport struct {
+	CheckedAt   time.Time     `json:"checkedAt"`
+	Day         time.Time     `json:"day"`
+	EventId     int           `json:"eventId"`
+	Stats       CanaryStats   `json:"stats,omitempty"`
+	Baseline    CanaryStats   `json:"baseline,omitempty"`
+	Drifts      []CanaryDrift `json:"drifts,omitempty"`
+	Error       string        `json:"error,omitempty"`
+	SourceError string        `json:"sourceError,omitempty"`
+}
+
+// IsHealthy reports whether canary documents were parsed without drift from baseline.
+func (r *CanaryReport) IsHealthy() bool {
+	return len(r.Error) == 0 && len(r.Drifts) == 0
+}
+
+// CanaryService periodically loads known day and event through parsers and compares field
+// coverage with stored baseline, so parser drift is detected before empty loads are stored.
+type CanaryService struct {
+	investingRepository InvestingDataReciver
+	sourceState         InvestingSourceState
+	logger              *log.Logger
+	config              *loader.Config
+	mu                  sync.RWMutex
+	last                *CanaryReport
+}
+
+func NewCanaryService(cnf *loader.Config,
+	logger *log.Logger,
+	investingRepository InvestingDataReciver,
+	sourceState InvestingSourceState) *CanaryService {
+
+	return &CanaryService{
+		investingRepository: investingRepository,
+		sourceState:         sourceState,
+		logger:              logger,
+		config:              cnf,
+	}
+}
+
+// Run is scheduled canary job, check is skipped while investing source is unavailable.
+func (s *CanaryService) Run(ctx context.Context) {
+	if !s.sourceState.IsAvailable() {
+		metrics.CanaryRuns.WithLabelValues(canaryResultSkipped).Inc()
+		s.logger.Warn("canary check skipped: investing source is unavailable")
+		return
+	}
+
+	report := s.Check(ctx, false)
+
+	if len(report.SourceError) > 0 {
+		s.logger.Warnf("canary check skipped: canary documents loading failed. %s", report.SourceError)
+		return
+	}
+
+	if report.IsHealthy() {
+		s.logger.Info("canary check complete successfully")
+		return
+	}
+
+	s.logger.WithField("drifts", report.Drifts).Errorf("canary check failed: parser drift detected. %s", report.Error)
+}
+
+// Check loads canary documents and compares their stats with baseline. Baseline is stored
+// from current stats when update is requested or when it doesn't exist yet and current stats
+// reach baseline minimums.
+func (s *CanaryService) Check(ctx context.Context, updateBaseline bool) *CanaryReport {
+	return s.check(ctx, updateBaseline, false)
+}
+
+// Verify compares canary documents stats with stored baseline without storing it, missing
+// baseline fails check.
+func (s *CanaryService) Verify(ctx context.Context) *CanaryReport {
+	return s.check(ctx, false, true)
+}
+
+func (s *CanaryService) check(ctx context.Context, updateBaseline, readOnly bool) *CanaryReport {
+	report := &CanaryReport{
+		CheckedAt: time.Now().UTC(),
+		Day:       s.config.Canary.Day,
+		EventId:   s.config.Canary.EventId,
+	}
+
+	defer s.complete(report)
+
+	stats, err := s.collectStats(ctx)
+	if investing.IsSourceError(err) {
+		report.SourceError = err.Error()
+		return report
+	}
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+
+	report.Stats = stats
+
+	baseline, err := s.loadBaseline()
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+
+	if baseline == nil && readOnly {
+		report.Error = fmt.Sprintf("canary baseline '%s' doesn't exist", s.config.Canary.Baseline)
+		return report
+	}
+
+	if baseline == nil && !updateBaseline {
+		if failed := compareCanaryMinimums(stats); len(failed) > 0 {
+			report.Drifts = failed
+			report.Error = fmt.Sprintf("canary baseline isn't stored: %d metrics are below minimums", len(failed))
+			return report
+		}
+	}
+
+	if baseline == nil || updateBaseline {
+		if err = s.saveBaseline(stats); err != nil {
+			report.Error = err.Error()
+			return report
+		}
+		s.logger.Infof("canary baseline stored into '%s'", s.config.Canary.Baseline)
+		baseline = stats
+	}
+
+	report.Baseline = baseline
+	report.Drifts = compareCanaryStats(baseline, stats, s.tolerance())
+
+	return report
+}
+
+// LastReport returns result of the latest canary check, nil when no check was done yet.
+func (s *CanaryService) LastReport() *CanaryReport {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.last
+}
+
+// complete stores report as the latest one. Report of documents failed to load keeps parse
+// result of the previous check, so source failures neither raise nor clear parser drift.
+func (s *CanaryService) complete(report *CanaryReport) {
+	s.mu.Lock()
+	if last := s.last; len(report.SourceError) > 0 && last != nil {
+		report.Stats, report.Baseline = last.Stats, last.Baseline
+		report.Drifts, report.Error = last.Drifts, last.Error
+	}
+	s.last = report
+	s.mu.Unlock()
+
+	for metric, value := range report.Stats {
+		metrics.CanaryStat.WithLabelValues(metric).Set(value)
+	}
+
+	result := canaryResultOk
+
+	switch {
+	case len(report.SourceError) > 0:
+		result = canaryResultSource
+	case len(report.Error) > 0:
+		result = canaryResultError
+	case len(report.Drifts) > 0:
+		result = canaryResultDrift
+	}
+
+	metrics.CanaryRuns.WithLabelValues(result).Inc()
+
+	// drift is unknown when documents weren't loaded, so the last one is kept
+	if result == canaryResultSource {
+		return
+	}
+
+	if report.IsHealthy() {
+		metrics.CanaryDrift.Set(0)
+	} else {
+		metrics.CanaryDrift.Set(1)
+	}
+}
+
+func (s *CanaryService) collectStats(ctx context.Context) (CanaryStats, error) {
+	day := s.config.Canary.Day
+
+	if day.IsZero() || s.config.Canary.EventId <= 0 || len(s.config.Canary.Baseline) == 0 {
+		return nil, fmt.Errorf("canary day, event or baseline file are not configured")
+	}
+
+	// rows are taken before parse mode is applied, so rows lenient parsing skips are seen as drift
+	result, err := s.investingRepository.ParseEventsScheduleByLanguage(ctx, s.config.Loading.DefaultLanguageId, day, day)
+	if err != nil {
+		return nil, fmt.Errorf("canary day '%s' loading error: %w", day.Format("2006-01-02"), err)
+	}
+
+	event, err := s.investingRepository.GetEventDetailsByLanguage(ctx, s.config.Canary.EventId, s.config.Loading.DefaultLanguageId)
+	if err != nil {
+		return nil, fmt.Errorf("canary event %d loading error: %w", s.config.Canary.EventId, err)
+	}
+
+	stats := scheduleCanaryStats(result)
+
+	for metric, value := range eventCanaryStats(event) {
+		stats[metric] = value
+	}
+
+	return stats, nil
+}
+
+func (s *CanaryService) tolerance() float64 {
+	if s.config.Canary.Tolerance > 0 {
+		return s.config.Canary.Tolerance
+	}
+	return defaultCanaryTolerance
+}
+
+func (s *CanaryService) loadBaseline() (CanaryStats, error) {
+	content, err := os.ReadFile(s.config.Canary.Baseline)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read canary baseline error: %w", err)
+	}
+
+	baseline := CanaryStats{}
+
+	if err = json.Unmarshal(content, &baseline); err != nil {
+		return nil, fmt.Errorf("parse canary baseline error: %w", err)
+	}
+
+	return baseline, nil
+}
+
+func (s *CanaryService) saveBaseline(stats CanaryStats) error {
+	content, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal canary baseline error: %w", err)
+	}
+
+	if err = os.WriteFile(s.config.Canary.Baseline, content, 0644); err != nil {
+		return fmt.Errorf("write canary baseline error: %w", err)
+	}
+
+	return nil
+}
+
+func scheduleCanaryStats(result *investing.ScheduleParseResult) CanaryStats {
+	rows := result.Rows
+
+	var titles, sentiments, countries, indexes, actuals int
+
+	for _, row := range rows {
+		if len(row.Title) > 0 {
+			titles++
+		}
+		if row.Sentiment >= 1 && row.Sentiment <= 3 {
+			sentiments++
+		}
+		if len(row.CountryName) > 0 {
+			countries++
+		}
+		if row.Type == investing.Index {
+			indexes++
+			if row.ActualValue != nil {
+				actuals++
+			}
+		}
+	}
+
+	return CanaryStats{
+		canaryScheduleRows:             float64(len(rows)),
+		"schedule_parsed_rate":         share(len(rows), len(rows)+len(result.Errors)),
+		"schedule_title_coverage":      share(titles, len(rows)),
+		"schedule_sentiment_coverage":  share(sentiments, len(rows)),
+		"schedule_country_coverage":    share(countries, len(rows)),
+		"schedule_numeric_actual_rate": share(actuals, indexes),
+	}
+}
+
+func eventCanaryStats(event *investing.InvestingCalendarEvent) CanaryStats {
+	flag := func(ok bool) float64 {
+		if ok {
+			return 1
+		}
+		return 0
+	}
+
+	return CanaryStats{
+		"event_title_present":    flag(len(event.Title) > 0),
+		"event_sentiment_valid":  flag(event.Sentiment >= 1 && event.Sentiment <= 3),
+		"event_country_present":  flag(len(event.Country) > 0),
+		"event_overview_present": flag(len(event.Overview) > 0),
+	}
+}
+
+// compareCanaryStats returns metrics drifted from baseline more than tolerance. Rates are
+// compared by absolute difference and schedule rows count by relative one.
+func compareCanaryStats(baseline, actual CanaryStats, tolerance float64) []CanaryDrift {
+	drifts := make([]CanaryDrift, 0)
+
+	for metric, expected := range baseline {
+		value, ok := actual[metric]
+
+		diff := math.Abs(value - expected)
+
+		if metric == canaryScheduleRows && expected > 0 {
+			diff = diff / expected
+		}
+
+		if !ok || diff > tolerance {
+			drifts = append(drifts, CanaryDrift{Metric: metric, Baseline: expected, Actual: value})
+		}
+	}
+
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].Metric < drifts[j].Metric
+	})
+
+	return drifts
+}
+
+// compareCanaryMinimums returns metrics which are lower than baseline minimums.
+func compareCanaryMinimums(actual CanaryStats) []CanaryDrift {
+	failed := make([]CanaryDrift, 0)
+
+	for metric, minimum := range canaryBaselineMinimums {
+		if value := actual[metric]; value < minimum {
+			failed = append(failed, CanaryDrift{Metric: metric, Baseline: minimum, Actual: value})
+		}
+	}
+
+	sort.Slice(failed, func(i, j int) bool {
+		return failed[i].Metric < failed[j].Metric
+	})
+
+	return failed
+}
+
+func share(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total)
+}
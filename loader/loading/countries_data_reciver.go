@@ -8,5 +8,5 @@ import (
 
 type CountriesDataReciver interface {
 	GetAll(ctx context.Context) ([]data.Country, error)
-	Save(ctx context.Context, c data.Country) error
+	Save(ctx context.Context, c data.Country) (data.WriteStats, error)
 }
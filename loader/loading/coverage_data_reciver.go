@@ -0,0 +1,14 @@
+package loading
+
+import (
+	"context"
+	"time"
+
+	"github.com/denis-gudim/economic-calendar/loader/data"
+)
+
+type CoverageDataReciver interface {
+	GetEmptyDays(ctx context.Context, from, to time.Time) ([]time.Time, error)
+	GetTitlesGaps(ctx context.Context, from, to time.Time, languageIds []int) ([]data.TitlesGap, error)
+	GetUntranslatedEvents(ctx context.Context) ([]int, error)
+}
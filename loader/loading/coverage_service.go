@@ -0,0 +1,154 @@
+package loading
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/denis-gudim/economic-calendar/loader"
+	"github.com/denis-gudim/economic-calendar/loader/investing"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type CoverageReport struct {
+	From               time.Time     `json:"from"`
+	To                 time.Time     `json:"to"`
+	EmptyDays          []time.Time   `json:"emptyDays"`
+	TitlesGaps         []CoverageGap `json:"titlesGaps"`
+	UntranslatedEvents []int         `json:"untranslatedEvents"`
+}
+
+type CoverageGap struct {
+	Date       time.Time `json:"date"`
+	LanguageId int       `json:"languageId"`
+	Count      int       `json:"count"`
+}
+
+// Days returns affected days with languages to reload, empty languages list means all languages.
+func (r *CoverageReport) Days() map[time.Time][]int {
+	days := make(map[time.Time][]int, len(r.EmptyDays)+len(r.TitlesGaps))
+
+	for _, date := range r.EmptyDays {
+		days[date] = nil
+	}
+
+	for _, gap := range r.TitlesGaps {
+		if languageIds, ok := days[gap.Date]; !ok || languageIds != nil {
+			days[gap.Date] = append(languageIds, gap.LanguageId)
+		}
+	}
+
+	return days
+}
+
+func (r *CoverageReport) IsEmpty() bool {
+	return len(r.EmptyDays) == 0 && len(r.TitlesGaps) == 0 && len(r.UntranslatedEvents) == 0
+}
+
+type CoverageService struct {
+	coverageRepository CoverageDataReciver
+	historyService     *HistoryLoaderService
+	logger             *log.Logger
+	config             *loader.Config
+}
+
+func NewCoverageService(cnf *loader.Config,
+	logger *log.Logger,
+	coverageRepository CoverageDataReciver,
+	historyService *HistoryLoaderService) *CoverageService {
+
+	return &CoverageService{
+		coverageRepository: coverageRepository,
+		historyService:     historyService,
+		logger:             logger,
+		config:             cnf,
+	}
+}
+
+// Analyse builds coverage report of stored history between dates (inclusive). Zero dates are
+// replaced with history loading bounds.
+func (s *CoverageService) Analyse(ctx context.Context, from, to time.Time) (*CoverageReport, error) {
+
+	fmtError := func(msg string, err error) error {
+		return fmt.Errorf("coverage analysis failed: %s: %w", msg, err)
+	}
+
+	if from.IsZero() {
+		from = s.config.Loading.FromTime
+	}
+
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+
+	report := CoverageReport{
+		From: truncateDay(from),
+		To:   truncateDay(to),
+	}
+
+	var err error
+
+	report.EmptyDays, err = s.coverageRepository.GetEmptyDays(ctx, report.From, report.To)
+
+	if err != nil {
+		return nil, fmtError("get empty days", err)
+	}
+
+	languageIds := make([]int, 0, len(investing.InvestingLanguagesMap))
+
+	for languageId := range investing.InvestingLanguagesMap {
+		languageIds = append(languageIds, languageId)
+	}
+
+	sort.Ints(languageIds)
+
+	gaps, err := s.coverageRepository.GetTitlesGaps(ctx, report.From, report.To, languageIds)
+
+	if err != nil {
+		return nil, fmtError("get titles gaps", err)
+	}
+
+	report.TitlesGaps = make([]CoverageGap, len(gaps))
+
+	for i, gap := range gaps {
+		report.TitlesGaps[i] = CoverageGap{
+			Date:       truncateDay(gap.Date),
+			LanguageId: gap.LanguageId,
+			Count:      gap.Count,
+		}
+	}
+
+	report.UntranslatedEvents, err = s.coverageRepository.GetUntranslatedEvents(ctx)
+
+	if err != nil {
+		return nil, fmtError("get untranslated events", err)
+	}
+
+	s.logger.Infof("coverage analysis finished: from = %s, to = %s, empty days = %d, titles gaps = %d, untranslated events = %d",
+		report.From.Format("2006-01-02"), report.To.Format("2006-01-02"),
+		len(report.EmptyDays), len(report.TitlesGaps), len(report.UntranslatedEvents))
+
+	return &report, nil
+}
+
+// Reload loads again only days and events affected by coverage report.
+func (s *CoverageService) Reload(ctx context.Context, report *CoverageReport) error {
+
+	fmtError := func(msg string, err error) error {
+		return fmt.Errorf("coverage reload failed: %s: %w", msg, err)
+	}
+
+	for _, eventId := range report.UntranslatedEvents {
+		if err := s.historyService.ReloadEvent(ctx, eventId); err != nil {
+			return fmtError(fmt.Sprintf("reload event %d", eventId), err)
+		}
+	}
+
+	if err := s.historyService.LoadDays(ctx, report.Days()); err != nil {
+		return fmtError("reload days", err)
+	}
+
+	return nil
+}
@@ -3,9 +3,11 @@ package loading
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/denis-gudim/economic-calendar/loader"
 	"github.com/denis-gudim/economic-calendar/loader/data"
+	"github.com/denis-gudim/economic-calendar/loader/metrics"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -30,7 +32,27 @@ func NewDictionariesLoaderService(cnf *loader.Config,
 	}
 }
 
+// Load loads countries translations when some of stored countries have no translations.
 func (s *DictionariesLoaderService) Load(ctx context.Context) error {
+	return s.observe(ctx, false)
+}
+
+// Reload loads countries translations regardless of stored ones.
+func (s *DictionariesLoaderService) Reload(ctx context.Context) error {
+	return s.observe(ctx, true)
+}
+
+func (s *DictionariesLoaderService) observe(ctx context.Context, force bool) error {
+	start := time.Now()
+
+	result, err := s.load(ctx, force)
+
+	metrics.ObserveJob(dictionariesJob, result, start)
+
+	return err
+}
+
+func (s *DictionariesLoaderService) load(ctx context.Context, force bool) (string, error) {
 
 	fmtError := func(err error) error {
 		return fmt.Errorf("countries dictionary loading failed: %w", err)
@@ -41,10 +63,10 @@ func (s *DictionariesLoaderService) Load(ctx context.Context) error {
 	countries, err := s.countriesRepository.GetAll(ctx)
 
 	if err != nil {
-		return fmtError(err)
+		return jobResultError, fmtError(err)
 	}
 
-	load := false
+	load := force
 
 	for _, c := range countries {
 		if len(c.NameTranslations) == 0 {
@@ -55,15 +77,17 @@ func (s *DictionariesLoaderService) Load(ctx context.Context) error {
 
 	if !load {
 		s.logger.Info("countries dictionary loading skiped")
-		return nil
+		return jobResultSkipped, nil
 	}
 
 	invCountries, err := s.investingRepository.GetCountries(ctx)
 
 	if err != nil {
-		return fmtError(err)
+		return jobResultError, fmtError(err)
 	}
 
+	stats := data.WriteStats{}
+
 	for _, c := range countries {
 		ic, ok := invCountries[c.Id]
 
@@ -77,15 +101,17 @@ func (s *DictionariesLoaderService) Load(ctx context.Context) error {
 			c.NameTranslations[icl.LanguageId] = icl.Title
 		}
 
-		err = s.countriesRepository.Save(ctx, c)
+		countryStats, err := s.countriesRepository.Save(ctx, c)
 
 		if err != nil {
-			return fmtError(err)
+			return jobResultError, fmtError(err)
 		}
 
+		stats.Add(countryStats)
+
 	}
 
-	s.logger.Info("countries dictionary loading finished")
+	s.logger.Infof("countries dictionary loading finished: %s", stats)
 
-	return nil
+	return jobResultOk, nil
 }
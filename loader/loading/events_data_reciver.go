@@ -8,5 +8,5 @@ import (
 
 type EventsDataReciver interface {
 	GetById(ctx context.Context, id int) (*data.Event, error)
-	Save(ctx context.Context, e data.Event) error
+	Save(ctx context.Context, e data.Event) (data.WriteStats, error)
 }
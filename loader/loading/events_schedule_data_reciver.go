@@ -10,5 +10,8 @@ import (
 type EventScheduleDataReciver interface {
 	GetFirst(ctx context.Context, done bool) (*data.EventSchedule, error)
 	GetByDates(ctx context.Context, from, to time.Time) ([]data.EventSchedule, error)
-	Save(ctx context.Context, es data.EventSchedule) error
+	GetReleases(ctx context.Context, from, to time.Time) ([]data.EventRelease, error)
+	GetNewestDoneTimeStamp(ctx context.Context) (time.Time, error)
+	Save(ctx context.Context, es data.EventSchedule) (data.WriteStats, error)
+	SaveBatch(ctx context.Context, rows []data.EventSchedule) (data.WriteStats, error)
 }
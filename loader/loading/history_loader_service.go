@@ -2,10 +2,14 @@ package loading
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/denis-gudim/economic-calendar/loader"
 	"github.com/denis-gudim/economic-calendar/loader/data"
+	"github.com/denis-gudim/economic-calendar/loader/investing"
+	"github.com/denis-gudim/economic-calendar/loader/metrics"
 
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
@@ -18,9 +22,16 @@ type HistoryLoaderService struct {
 	countriesRepository     CountriesDataReciver
 	eventsRepository        EventsDataReciver
 	eventScheduleRepository EventScheduleDataReciver
+	holidaysRepository      HolidaysDataReciver
+	loadStatesRepository    LoadStatesDataReciver
+	sourceState             InvestingSourceState
 	logger                  *log.Logger
 	config                  *loader.Config
 	countriesMap            map[string]int
+	scheduleStats           data.WriteStats
+	eventsStats             data.WriteStats
+	// jobLock serialises scheduled loading and reloads sharing countries map and stats
+	jobLock sync.Mutex
 }
 
 func NewHistoryLoaderService(cnf *loader.Config,
@@ -28,13 +39,19 @@ func NewHistoryLoaderService(cnf *loader.Config,
 	investingRepository InvestingDataReciver,
 	countriesRepository CountriesDataReciver,
 	eventsRepository EventsDataReciver,
-	eventScheduleRepository EventScheduleDataReciver) *HistoryLoaderService {
+	eventScheduleRepository EventScheduleDataReciver,
+	holidaysRepository HolidaysDataReciver,
+	loadStatesRepository LoadStatesDataReciver,
+	sourceState InvestingSourceState) *HistoryLoaderService {
 
 	return &HistoryLoaderService{
 		investingRepository:     investingRepository,
 		countriesRepository:     countriesRepository,
 		eventsRepository:        eventsRepository,
 		eventScheduleRepository: eventScheduleRepository,
+		holidaysRepository:      holidaysRepository,
+		loadStatesRepository:    loadStatesRepository,
+		sourceState:             sourceState,
 		logger:                  logger,
 		config:                  cnf,
 	}
@@ -46,6 +63,26 @@ func (s *HistoryLoaderService) Load(ctx context.Context) {
 		return fmt.Errorf("events schedule loading failed: %s: %w", msg, err)
 	}
 
+	start := time.Now()
+	result := jobResultOk
+
+	defer func() {
+		metrics.ObserveJob(historyJob, result, start)
+	}()
+
+	if !s.sourceState.IsAvailable() {
+		result = jobResultSkipped
+		s.logger.Warn("events history loading skipped: investing source is unavailable")
+		return
+	}
+
+	if !s.jobLock.TryLock() {
+		result = jobResultSkipped
+		s.logger.Warn("events history loading skipped: history reload is running")
+		return
+	}
+	defer s.jobLock.Unlock()
+
 	s.logger.Info("events history loading started...")
 
 	err := s.fillCountriesMap(ctx)
@@ -54,47 +91,167 @@ func (s *HistoryLoaderService) Load(ctx context.Context) {
 		s.logger.Error(fmtError("fill countries map", err))
 	}
 
-	from, to, err := s.getHistoryLoadingDates(ctx)
+	seeded, err := s.loadStatesRepository.Seed(ctx, truncateDay(time.Now().UTC()))
 
 	if err != nil {
-		s.logger.Error(fmtError("loading dates calculation", err))
+		s.logger.Error(fmtError("seed load states", err))
+	} else if seeded > 0 {
+		s.logger.Infof("history load states seeded from stored events schedule: count = %d", seeded)
+	}
+
+	days, err := s.getHistoryLoadingDays(ctx)
+
+	if err != nil {
+		result = jobResultError
+		s.logger.Error(fmtError("loading days calculation", err))
 		return
 	}
 
-	_ctx, cancelFunc := context.WithCancel(ctx)
+	if failed := s.loadDays(ctx, days); failed > 0 {
+		result = jobResultFailed
+	}
 
-	defer cancelFunc()
+	updateNewestScheduleMetric(ctx, s.eventScheduleRepository, s.logger)
+}
 
-	out1, errc1 := s.loadInvestingSchedule(_ctx, from, to)
-	out2, errc2 := s.loadInvestingEvents(_ctx, out1)
+// LoadDays reloads specified days, languages list of a day being empty means all languages.
+// Load states of reloaded days are updated like during regular history loading.
+func (s *HistoryLoaderService) LoadDays(ctx context.Context, targets map[time.Time][]int) error {
 
-	for item := range out2 {
-		err = s.eventScheduleRepository.Save(ctx, item)
+	if len(targets) == 0 {
+		return nil
+	}
 
-		if err != nil {
-			s.logger.Error(fmtError("save loaded schedule items", err))
-			break
+	s.jobLock.Lock()
+	defer s.jobLock.Unlock()
+
+	if err := s.fillCountriesMap(ctx); err != nil {
+		return fmt.Errorf("fill countries map: %w", err)
+	}
+
+	var from, to time.Time
+
+	for date := range targets {
+		if from.IsZero() || date.Before(from) {
+			from = date
 		}
+		if to.IsZero() || date.After(to) {
+			to = date
+		}
+	}
 
-		s.logger.Infof("new event schedule row stored to database: id = %d, eventId = %d", item.Id, item.EventId)
+	states, err := s.loadStatesRepository.GetByDates(ctx, from, to)
+
+	if err != nil {
+		return fmt.Errorf("get history load states: %w", err)
 	}
 
-	select {
-	case err := <-errc1:
-		if err != nil {
-			s.logger.Error(fmtError("load investing schedule", err))
+	stored := make(map[time.Time]map[int]data.LoadState)
+
+	for _, state := range states {
+		date := truncateDay(state.Date)
+
+		if _, ok := stored[date]; !ok {
+			stored[date] = make(map[int]data.LoadState)
 		}
-	case err := <-errc2:
-		if err != nil {
-			s.logger.Error(fmtError("load investing events", err))
+
+		stored[date][state.LanguageId] = state
+	}
+
+	days := make([]historyDay, 0, len(targets))
+
+	for date, languageIds := range targets {
+
+		date = truncateDay(date)
+
+		if len(languageIds) == 0 {
+			for languageId := range investing.InvestingLanguagesMap {
+				languageIds = append(languageIds, languageId)
+			}
 		}
-	case <-_ctx.Done():
-		{
+
+		day := historyDay{
+			date:   date,
+			states: make(map[int]data.LoadState, len(languageIds)),
+		}
+
+		for _, languageId := range languageIds {
+
+			state, ok := stored[date][languageId]
+
+			if !ok {
+				state = data.LoadState{Date: date, LanguageId: languageId}
+			}
+
+			day.states[languageId] = state
+		}
+
+		days = append(days, day)
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].date.After(days[j].date)
+	})
+
+	failed := s.loadDays(ctx, days)
+
+	if !s.sourceState.IsAvailable() {
+		return investing.ErrSourceUnavailable
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d days failed to load", failed, len(days))
+	}
+
+	return ctx.Err()
+}
+
+// ReloadEvent loads event details from source and stores them even if event already exists.
+func (s *HistoryLoaderService) ReloadEvent(ctx context.Context, eventId int) error {
+
+	s.jobLock.Lock()
+	defer s.jobLock.Unlock()
+
+	if err := s.fillCountriesMap(ctx); err != nil {
+		return fmt.Errorf("fill countries map: %w", err)
+	}
+
+	return s.saveEvent(ctx, eventId)
+}
+
+func (s *HistoryLoaderService) loadDays(ctx context.Context, days []historyDay) (failed int) {
+
+	s.logger.Infof("events history loading days found: count = %d", len(days))
+
+	s.scheduleStats = data.WriteStats{}
+	s.eventsStats = data.WriteStats{}
+
+	for i, day := range days {
+
+		if ctx.Err() != nil {
 			s.logger.Info("events history loading canceled")
+			return
 		}
-	default:
-		s.logger.Info("events history loading finished")
+
+		if !s.sourceState.IsAvailable() {
+			s.logger.Warnf("events history loading stopped: investing source is unavailable: days left = %d", len(days)-i)
+			return
+		}
+
+		if err := s.loadDay(ctx, day); err != nil {
+			failed++
+			s.logger.Errorf("events schedule loading failed: load day %s: %s", day.date.Format("2006-01-02"), err)
+		}
+
+		s.logger.Infof("events history loading progress: %d/%d days (%.1f%%), failed = %d, date = %s",
+			i+1, len(days), float64(i+1)*100/float64(len(days)), failed, day.date.Format("2006-01-02"))
 	}
+
+	s.logger.Infof("events history loading finished: days = %d, failed = %d", len(days), failed)
+	s.logger.Infof("events history loading stored schedule rows: %s", s.scheduleStats)
+	s.logger.Infof("events history loading stored events: %s", s.eventsStats)
+
+	return
 }
 
 func (s *HistoryLoaderService) fillCountriesMap(ctx context.Context) error {
@@ -115,166 +272,430 @@ func (s *HistoryLoaderService) fillCountriesMap(ctx context.Context) error {
 	return nil
 }
 
-func (s *HistoryLoaderService) getHistoryLoadingDates(ctx context.Context) (time.Time, time.Time, error) {
-	var from, to time.Time
+// historyDay is calendar day with load states of languages which still have to be loaded.
+type historyDay struct {
+	date   time.Time
+	states map[int]data.LoadState
+}
+
+// getHistoryLoadingDays returns days from newest to oldest which have missing, failed or
+// interrupted languages. Day is final only when it was loaded after the day had ended.
+func (s *HistoryLoaderService) getHistoryLoadingDays(ctx context.Context) ([]historyDay, error) {
+	from := truncateDay(s.config.Loading.FromTime)
+	to := truncateDay(time.Now().UTC()).AddDate(0, 0, s.config.Loading.ToDays)
+
+	states, err := s.loadStatesRepository.GetByDates(ctx, from, to)
 
-	fromRow, err := s.eventScheduleRepository.GetFirst(ctx, true)
 	if err != nil {
-		return time.Time{}, time.Time{}, fmt.Errorf("get first completed events schedule row: %w", err)
-	} else if fromRow != nil {
-		from = time.Unix(fromRow.TimeStamp.Unix()/daySec*daySec, 0)
+		return nil, fmt.Errorf("get history load states: %w", err)
 	}
 
-	toRow, err := s.eventScheduleRepository.GetFirst(ctx, false)
-	if err != nil {
-		return time.Time{}, time.Time{}, fmt.Errorf("get first uncompleted events schedule row: %w", err)
-	} else if toRow != nil {
-		to = time.Unix(toRow.TimeStamp.Unix()/daySec*daySec, 0)
+	stored := make(map[time.Time]map[int]data.LoadState)
+
+	for _, state := range states {
+		date := truncateDay(state.Date)
+
+		if _, ok := stored[date]; !ok {
+			stored[date] = make(map[int]data.LoadState, len(investing.InvestingLanguagesMap))
+		}
+
+		stored[date][state.LanguageId] = state
+	}
+
+	days := make([]historyDay, 0, 64)
+
+	for date := to; !date.Before(from); date = date.AddDate(0, 0, -1) {
+
+		day := historyDay{
+			date:   date,
+			states: make(map[int]data.LoadState),
+		}
+
+		for languageId := range investing.InvestingLanguagesMap {
+
+			state, ok := stored[date][languageId]
+
+			if !ok {
+				state = data.LoadState{Date: date, LanguageId: languageId}
+			}
+
+			if state.Status == data.LoadStatusDone && !state.UpdatedAt.Before(date.AddDate(0, 0, 1)) {
+				continue
+			}
+
+			day.states[languageId] = state
+		}
+
+		if len(day.states) > 0 {
+			days = append(days, day)
+		}
 	}
 
-	return from, to, nil
+	return days, nil
 }
 
-func (s *HistoryLoaderService) loadInvestingSchedule(ctx context.Context, from, to time.Time) (<-chan data.EventSchedule, <-chan error) {
+// loadDay loads pending languages of the day and stores loading result of every language.
+// Default language is always loaded as it's used for countries mapping of holidays.
+func (s *HistoryLoaderService) loadDay(ctx context.Context, day historyDay) error {
 
-	out := make(chan data.EventSchedule, 1024)
-	errc := make(chan error, 1)
+	languageIds := make([]int, 0, len(day.states)+1)
+	languageIds = append(languageIds, s.config.Loading.DefaultLanguageId)
 
-	go func() {
-		defer close(out)
-		defer close(errc)
+	for languageId, state := range day.states {
 
-		date := time.Unix((time.Now().UTC().Unix()/daySec+int64(s.config.Loading.ToDays))*daySec, 0)
+		if languageId != s.config.Loading.DefaultLanguageId {
+			languageIds = append(languageIds, languageId)
+		}
 
-		for !date.Before(s.config.Loading.FromTime) {
+		state.Status = data.LoadStatusLoading
+		state.Attempts++
+		state.UpdatedAt = time.Now().UTC()
 
-			if !date.After(from) || !date.Before(to) {
+		if err := s.loadStatesRepository.Save(ctx, state); err != nil {
+			return err
+		}
 
-				batch, err := s.investingRepository.GetEventsSchedule(ctx, date, date)
+		day.states[languageId] = state
+	}
 
-				if err != nil {
-					errc <- err
-					break
-				}
+	calendars, errs := s.loadDayCalendars(ctx, day.date, languageIds)
 
-				s.logger.Infof("events schedule history batch loaded: date = %s, count = %d", date, len(batch))
+	var err error
 
-				for rowId, translations := range batch {
+	if err = errs[s.config.Loading.DefaultLanguageId]; err == nil {
+		err = s.saveCalendar(ctx, mergeCalendars(calendars, languageIds))
+	}
 
-					langItem := translations[0]
+	var dayErr error
 
-					if len(translations) == 0 {
-						errc <- fmt.Errorf("translations list is empty")
-						return
-					}
+	for languageId, state := range day.states {
 
-					newScheduleRow := data.EventSchedule{
-						Id:                rowId,
-						TimeStamp:         langItem.TimeStamp,
-						Actual:            langItem.Actual,
-						Forecast:          langItem.Forecast,
-						Previous:          langItem.Previous,
-						IsDone:            langItem.IsDone(time.Now().UTC()),
-						Type:              int(langItem.Type),
-						EventId:           langItem.EventId,
-						TitleTranslations: data.Translations{},
-					}
+		state.UpdatedAt = time.Now().UTC()
 
-					for _, langItem = range translations {
-						newScheduleRow.TitleTranslations[langItem.LanguageId] = langItem.Title
-					}
+		switch {
+		case err != nil:
+			state.Status = data.LoadStatusFailed
+			state.LastError = err.Error()
+		case errs[languageId] != nil:
+			state.Status = data.LoadStatusFailed
+			state.LastError = errs[languageId].Error()
+		default:
+			state.Status = data.LoadStatusDone
+			state.RowCount = len(calendars[languageId].Schedule)
+			state.LastError = ""
+		}
 
-					select {
-					case out <- newScheduleRow:
-					case <-ctx.Done():
-						return
-					}
-				}
-			}
+		if state.Status == data.LoadStatusFailed {
+			dayErr = fmt.Errorf("language %d attempt %d: %s", languageId, state.Attempts, state.LastError)
+		}
 
-			date = date.AddDate(0, 0, -1)
+		if e := s.loadStatesRepository.Save(ctx, state); e != nil {
+			return e
 		}
-	}()
+	}
 
-	return out, errc
+	if dayErr == nil {
+		s.logger.Infof("events schedule history day loaded: date = %s, languages = %d, count = %d",
+			day.date.Format("2006-01-02"), len(day.states), len(calendars[s.config.Loading.DefaultLanguageId].Schedule))
+	}
+
+	return dayErr
 }
 
-func (s *HistoryLoaderService) loadInvestingEvents(ctx context.Context, in <-chan data.EventSchedule) (<-chan data.EventSchedule, <-chan error) {
+func (s *HistoryLoaderService) loadDayCalendars(ctx context.Context, date time.Time, languageIds []int) (map[int]*investing.InvestingCalendar, map[int]error) {
+
+	type result struct {
+		languageId int
+		calendar   *investing.InvestingCalendar
+		err        error
+	}
+
+	batchSize := 1
 
-	out := make(chan data.EventSchedule, 1024)
-	errc := make(chan error, 1)
+	if s.config.Loading.BatchSize > 0 {
+		batchSize = s.config.Loading.BatchSize
+	}
 
-	go func() {
-		defer close(out)
-		defer close(errc)
+	resultsChannel := make(chan result, len(languageIds))
+	poolChannel := make(chan struct{}, batchSize)
 
-		for scheduleRow := range in {
+	for _, languageId := range languageIds {
 
-			event, err := s.eventsRepository.GetById(ctx, scheduleRow.EventId)
+		poolChannel <- struct{}{}
 
-			if err != nil {
-				errc <- err
-				return
-			}
+		go func(languageId int) {
+
+			calendar, err := s.investingRepository.GetCalendarByLanguage(ctx, languageId, date, date)
+
+			resultsChannel <- result{languageId, calendar, err}
+			<-poolChannel
+		}(languageId)
+	}
 
-			if event == nil {
+	calendars := make(map[int]*investing.InvestingCalendar, len(languageIds))
+	errs := make(map[int]error)
 
-				translations, err := s.investingRepository.GetEventDetails(ctx, scheduleRow.EventId)
+	for range languageIds {
+		r := <-resultsChannel
 
-				s.logger.Infof("event details loaded from source: eventId = %d", scheduleRow.EventId)
+		if r.err != nil {
+			errs[r.languageId] = r.err
+			continue
+		}
 
-				if err != nil {
-					errc <- err
-					return
-				}
+		calendars[r.languageId] = r.calendar
+	}
 
-				if len(translations) == 0 {
-					errc <- fmt.Errorf("translations list is empty")
-					return
-				}
+	return calendars, errs
+}
 
-				langItem := translations[0]
+func (s *HistoryLoaderService) saveCalendar(ctx context.Context, calendar *investing.InvestingCalendar) error {
 
-				countryId, ok := s.countriesMap[langItem.Country]
+	if err := s.saveHolidays(ctx, calendar.Holidays); err != nil {
+		return err
+	}
 
-				if !ok {
-					errc <- fmt.Errorf("country with name '%s' not found in map", langItem.Country)
-					return
-				}
+	rows := make([]data.EventSchedule, 0, len(calendar.Schedule))
 
-				newEvent := data.Event{
-					Id:                   scheduleRow.EventId,
-					CountryId:            countryId,
-					ImpactLevel:          langItem.Sentiment,
-					Unit:                 langItem.Unit,
-					Source:               langItem.Source,
-					SourceUrl:            langItem.SourceUrl,
-					TitleTranslations:    data.Translations{},
-					OverviewTranslations: data.Translations{},
-				}
+	for rowId, translations := range calendar.Schedule {
 
-				for _, langItem = range translations {
-					newEvent.TitleTranslations[langItem.LanguageId] = langItem.Title
-					newEvent.OverviewTranslations[langItem.LanguageId] = langItem.Overview
-				}
+		scheduleRow, err := newEventSchedule(rowId, translations)
 
-				err = s.eventsRepository.Save(ctx, newEvent)
+		if err != nil {
+			return err
+		}
 
-				if err != nil {
-					errc <- err
-					return
-				}
+		if err = s.loadEvent(ctx, scheduleRow.EventId); err != nil {
+			return err
+		}
 
-				s.logger.Infof("new event details stored to database: id = %d", newEvent.Id)
-			}
+		rows = append(rows, scheduleRow)
+	}
 
-			select {
-			case out <- scheduleRow:
-			case <-ctx.Done():
-				return
-			}
+	// rows of the day are saved by one batch, rows of failed batch are saved one by one,
+	// so single bad row doesn't drop the whole day
+	stats, err := s.eventScheduleRepository.SaveBatch(ctx, rows)
+
+	if err != nil && ctx.Err() == nil {
+		s.logger.Warnf("events schedule batch save failed, saving rows one by one: %s", err)
+		stats, err = s.saveScheduleRows(ctx, rows)
+	}
+
+	s.scheduleStats.Add(stats)
+
+	if err != nil {
+		return err
+	}
+
+	s.logger.Debugf("events schedule rows stored to database: %s", stats)
+
+	return nil
+}
+
+// saveScheduleRows saves rows one by one, failed rows are logged and reported by error
+// after the rest rows are saved.
+func (s *HistoryLoaderService) saveScheduleRows(ctx context.Context, rows []data.EventSchedule) (data.WriteStats, error) {
+
+	stats := data.WriteStats{}
+	failed := 0
+
+	var lastErr error
+
+	for _, row := range rows {
+
+		if ctx.Err() != nil {
+			return stats, ctx.Err()
 		}
-	}()
 
-	return out, errc
+		rowStats, err := s.eventScheduleRepository.Save(ctx, row)
+
+		if err != nil {
+			failed++
+			lastErr = err
+			s.logger.WithFields(log.Fields{"id": row.Id, "eventId": row.EventId, "timestamp": row.TimeStamp}).
+				Errorf("events schedule row save failed: %s", err)
+			continue
+		}
+
+		stats.Add(rowStats)
+	}
+
+	if failed > 0 {
+		return stats, fmt.Errorf("%d of %d events schedule rows failed to save: %w", failed, len(rows), lastErr)
+	}
+
+	return stats, nil
+}
+
+// loadEvent loads event details from source when event isn't stored yet.
+func (s *HistoryLoaderService) loadEvent(ctx context.Context, eventId int) error {
+
+	event, err := s.eventsRepository.GetById(ctx, eventId)
+
+	if err != nil {
+		return err
+	}
+
+	if event != nil {
+		return nil
+	}
+
+	return s.saveEvent(ctx, eventId)
+}
+
+func (s *HistoryLoaderService) saveEvent(ctx context.Context, eventId int) error {
+
+	translations, err := s.investingRepository.GetEventDetails(ctx, eventId)
+
+	s.logger.Infof("event details loaded from source: eventId = %d", eventId)
+
+	if err != nil {
+		return err
+	}
+
+	if len(translations) == 0 {
+		return fmt.Errorf("translations list is empty")
+	}
+
+	langItem := translations[0]
+
+	countryId, ok := s.countriesMap[langItem.Country]
+
+	if !ok {
+		return fmt.Errorf("country with name '%s' not found in map", langItem.Country)
+	}
+
+	newEvent := data.Event{
+		Id:                   eventId,
+		CountryId:            countryId,
+		ImpactLevel:          langItem.Sentiment,
+		Unit:                 langItem.Unit,
+		Source:               langItem.Source,
+		SourceUrl:            langItem.SourceUrl,
+		TitleTranslations:    data.Translations{},
+		OverviewTranslations: data.Translations{},
+	}
+
+	for _, langItem = range translations {
+		newEvent.TitleTranslations[langItem.LanguageId] = langItem.Title
+		newEvent.OverviewTranslations[langItem.LanguageId] = langItem.Overview
+	}
+
+	stats, err := s.eventsRepository.Save(ctx, newEvent)
+
+	if err != nil {
+		return err
+	}
+
+	s.eventsStats.Add(stats)
+
+	s.logger.Infof("new event details stored to database: id = %d", newEvent.Id)
+
+	return nil
+}
+
+func (s *HistoryLoaderService) saveHolidays(ctx context.Context, holidays map[int][]*investing.InvestingHoliday) error {
+
+	batch := make([]data.Holiday, 0, len(holidays))
+
+	for holidayId, translations := range holidays {
+
+		if len(translations) == 0 {
+			return fmt.Errorf("translations list is empty")
+		}
+
+		langItem := translations[0]
+
+		countryId, ok := s.countriesMap[langItem.CountryName]
+
+		if !ok {
+			s.logger.Warnf("holiday skipped. country with name '%s' not found in map: id = %d", langItem.CountryName, holidayId)
+			continue
+		}
+
+		holiday := data.Holiday{
+			Id:                holidayId,
+			CountryId:         countryId,
+			Date:              langItem.Date,
+			TitleTranslations: data.Translations{},
+		}
+
+		for _, langItem = range translations {
+			holiday.TitleTranslations[langItem.LanguageId] = langItem.Title
+		}
+
+		batch = append(batch, holiday)
+	}
+
+	return s.holidaysRepository.SaveBatch(ctx, batch)
+}
+
+func newEventSchedule(rowId int, translations []*investing.InvestingScheduleRow) (data.EventSchedule, error) {
+	if len(translations) == 0 {
+		return data.EventSchedule{}, fmt.Errorf("translations list is empty")
+	}
+
+	langItem := translations[0]
+
+	scheduleRow := data.EventSchedule{
+		Id:                  rowId,
+		TimeStamp:           langItem.TimeStamp,
+		Actual:              langItem.Actual,
+		Forecast:            langItem.Forecast,
+		Previous:            langItem.Previous,
+		ActualValue:         newIndexValue(langItem.ActualValue),
+		ForecastValue:       newIndexValue(langItem.ForecastValue),
+		PreviousValue:       newIndexValue(langItem.PreviousValue),
+		PreviousRevisedFrom: newIndexValue(langItem.PreviousRevisedFrom),
+		IsDone:              langItem.IsDone(time.Now().UTC()),
+		Type:                int(langItem.Type),
+		EventId:             langItem.EventId,
+		TitleTranslations:   data.Translations{},
+	}
+
+	for _, langItem = range translations {
+		scheduleRow.TitleTranslations[langItem.LanguageId] = langItem.Title
+	}
+
+	return scheduleRow, nil
+}
+
+func newIndexValue(v *investing.IndexValue) *data.IndexValue {
+	if v == nil {
+		return nil
+	}
+
+	return &data.IndexValue{
+		Raw:   v.Raw,
+		Value: v.Normalized(),
+		Scale: v.Scale,
+		Unit:  v.Unit,
+	}
+}
+
+// mergeCalendars joins single language calendars into translations lists ordered by languages.
+func mergeCalendars(calendars map[int]*investing.InvestingCalendar, languageIds []int) *investing.InvestingCalendar {
+	merged := &investing.InvestingCalendar{
+		Schedule: make(map[int][]*investing.InvestingScheduleRow),
+		Holidays: make(map[int][]*investing.InvestingHoliday),
+	}
+
+	for _, languageId := range languageIds {
+
+		calendar, ok := calendars[languageId]
+
+		if !ok {
+			continue
+		}
+
+		for id, rows := range calendar.Schedule {
+			merged.Schedule[id] = append(merged.Schedule[id], rows...)
+		}
+
+		for id, holidays := range calendar.Holidays {
+			merged.Holidays[id] = append(merged.Holidays[id], holidays...)
+		}
+	}
+
+	return merged
 }
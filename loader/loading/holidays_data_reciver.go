@@ -0,0 +1,12 @@
+package loading
+
+import (
+	"context"
+
+	"github.com/denis-gudim/economic-calendar/loader/data"
+)
+
+type HolidaysDataReciver interface {
+	Save(ctx context.Context, h data.Holiday) error
+	SaveBatch(ctx context.Context, holidays []data.Holiday) error
+}
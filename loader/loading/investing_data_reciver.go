@@ -9,7 +9,11 @@ import (
 
 type InvestingDataReciver interface {
 	GetEventsSchedule(ctx context.Context, dateFrom, dateTo time.Time) (map[int][]*investing.InvestingScheduleRow, error)
+	GetCalendar(ctx context.Context, dateFrom, dateTo time.Time) (*investing.InvestingCalendar, error)
+	GetCalendarByLanguage(ctx context.Context, languageId int, dateFrom, dateTo time.Time) (*investing.InvestingCalendar, error)
 	GetEventsScheduleByLanguage(ctx context.Context, languageId int, dateFrom, dateTo time.Time) ([]*investing.InvestingScheduleRow, error)
+	ParseEventsScheduleByLanguage(ctx context.Context, languageId int, dateFrom, dateTo time.Time) (*investing.ScheduleParseResult, error)
 	GetEventDetails(ctx context.Context, eventId int) ([]*investing.InvestingCalendarEvent, error)
+	GetEventDetailsByLanguage(ctx context.Context, eventId, languageId int) (*investing.InvestingCalendarEvent, error)
 	GetCountries(ctx context.Context) (map[int][]*investing.InvestingCountry, error)
 }
@@ -0,0 +1,7 @@
+package loading
+
+// InvestingSourceState reports whether investing source accepts requests, jobs are skipped
+// while source circuit breaker is open.
+type InvestingSourceState interface {
+	IsAvailable() bool
+}
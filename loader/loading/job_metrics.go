@@ -0,0 +1,35 @@
+package loading
+
+import (
+	"context"
+
+	"github.com/denis-gudim/economic-calendar/loader/metrics"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	historyJob      = "history"
+	refreshJob      = "refresh"
+	dictionariesJob = "dictionaries"
+
+	jobResultOk      = "ok"
+	jobResultSkipped = "skipped"
+	jobResultFailed  = "failed"
+	jobResultError   = "error"
+)
+
+// updateNewestScheduleMetric exports timestamp of the newest stored done schedule row, so
+// stalled loading is visible even when jobs complete without errors.
+func updateNewestScheduleMetric(ctx context.Context, repository EventScheduleDataReciver, logger *log.Logger) {
+	newest, err := repository.GetNewestDoneTimeStamp(ctx)
+
+	if err != nil {
+		logger.Errorf("newest schedule timestamp metric update failed: %s", err)
+		return
+	}
+
+	if !newest.IsZero() {
+		metrics.ScheduleNewestTimestamp.Set(float64(newest.Unix()))
+	}
+}
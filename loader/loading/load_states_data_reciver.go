@@ -0,0 +1,14 @@
+package loading
+
+import (
+	"context"
+	"time"
+
+	"github.com/denis-gudim/economic-calendar/loader/data"
+)
+
+type LoadStatesDataReciver interface {
+	GetByDates(ctx context.Context, from, to time.Time) ([]data.LoadState, error)
+	Save(ctx context.Context, state data.LoadState) error
+	Seed(ctx context.Context, before time.Time) (int, error)
+}
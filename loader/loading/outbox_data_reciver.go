@@ -0,0 +1,16 @@
+package loading
+
+import (
+	"context"
+	"time"
+
+	"github.com/denis-gudim/economic-calendar/loader/data"
+)
+
+type OutboxDataReciver interface {
+	GetPendingCount(ctx context.Context) (int, error)
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]data.OutboxRecord, error)
+	MarkPublished(ctx context.Context, record data.OutboxRecord) error
+	MarkRetry(ctx context.Context, record data.OutboxRecord, publishErr error, nextAttemptAt time.Time) error
+	MarkFailed(ctx context.Context, record data.OutboxRecord, publishErr error) error
+}
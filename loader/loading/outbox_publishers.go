@@ -0,0 +1,113 @@
+package loading
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/denis-gudim/economic-calendar/loader"
+	"github.com/denis-gudim/economic-calendar/loader/data"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultWebhookTimeout = 10 * time.Second
+
+// NewOutboxPublishers creates publishers listed in outbox configuration, empty list disables
+// outbox relay.
+func NewOutboxPublishers(cnf *loader.Config, logger *log.Logger) ([]OutboxPublisher, error) {
+	publishers := make([]OutboxPublisher, 0, len(cnf.Outbox.Publishers))
+
+	for _, name := range cnf.Outbox.Publishers {
+		switch strings.TrimSpace(name) {
+		case "":
+		case "log":
+			publishers = append(publishers, NewLogPublisher(logger))
+		case "webhook":
+			if len(cnf.Outbox.WebhookUrl) == 0 {
+				return nil, fmt.Errorf("outbox webhook publisher: webhook url isn't configured")
+			}
+			publishers = append(publishers, NewWebhookPublisher(cnf.Outbox.WebhookUrl, cnf.Outbox.WebhookTimeout))
+		default:
+			return nil, fmt.Errorf("unknown outbox publisher '%s'", name)
+		}
+	}
+
+	return publishers, nil
+}
+
+// LogPublisher writes outbox records into loader log.
+type LogPublisher struct {
+	logger *log.Logger
+}
+
+func NewLogPublisher(logger *log.Logger) *LogPublisher {
+	return &LogPublisher{logger: logger}
+}
+
+func (p *LogPublisher) Name() string {
+	return "log"
+}
+
+func (p *LogPublisher) Publish(ctx context.Context, record data.OutboxRecord) error {
+	p.logger.WithField("record", record).Info("event schedule changed")
+	return nil
+}
+
+// WebhookPublisher posts outbox records as JSON to webhook url, any response status except
+// 2xx is publish error. Record id is sent as Idempotency-Key header.
+type WebhookPublisher struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookPublisher(url string, timeout time.Duration) *WebhookPublisher {
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+
+	return &WebhookPublisher{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *WebhookPublisher) Name() string {
+	return "webhook"
+}
+
+func (p *WebhookPublisher) Publish(ctx context.Context, record data.OutboxRecord) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal record error: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request error: %w", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Idempotency-Key", strconv.FormatInt(record.Id, 10))
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("do request error: %w", err)
+	}
+	defer response.Body.Close()
+
+	// body is drained so connection can be reused for next requests
+	_, _ = io.CopyN(io.Discard, response.Body, 4096)
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %s", response.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package loading
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/denis-gudim/economic-calendar/loader"
+	"github.com/denis-gudim/economic-calendar/loader/data"
+	"github.com/denis-gudim/economic-calendar/loader/metrics"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultOutboxBatchSize   = 100
+	defaultOutboxMaxAttempts = 10
+	defaultOutboxMinBackoff  = 5 * time.Second
+	defaultOutboxMaxBackoff  = 10 * time.Minute
+	defaultOutboxLease       = 30 * time.Minute
+	outboxResultOk           = "ok"
+	outboxResultError        = "error"
+)
+
+// OutboxPublisher delivers outbox records to external system. Records are delivered at least
+// once, so publishers and their consumers should tolerate repeated records by record id.
+type OutboxPublisher interface {
+	Name() string
+	Publish(ctx context.Context, record data.OutboxRecord) error
+}
+
+// OutboxRelay delivers event schedule changes written into outbox by loader to publishers.
+// Records are claimed in short transaction and published outside of it. Record is marked as
+// published when every publisher accepted it, failed record is retried after backoff and delays
+// only following records of the same schedule row, so records are delivered in order by row.
+type OutboxRelay struct {
+	repository OutboxDataReciver
+	publishers []OutboxPublisher
+	logger     *log.Logger
+	config     *loader.Config
+}
+
+func NewOutboxRelay(cnf *loader.Config,
+	logger *log.Logger,
+	repository OutboxDataReciver,
+	publishers []OutboxPublisher) *OutboxRelay {
+
+	return &OutboxRelay{
+		repository: repository,
+		publishers: publishers,
+		logger:     logger,
+		config:     cnf,
+	}
+}
+
+// IsEnabled reports whether any publisher is configured.
+func (r *OutboxRelay) IsEnabled() bool {
+	return len(r.publishers) > 0
+}
+
+// Run is scheduled relay job, due records are claimed and published in batches till no due
+// records are left.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	batchSize := r.config.Outbox.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultOutboxBatchSize
+	}
+
+	lease := r.config.Outbox.Lease
+	if lease <= 0 {
+		lease = defaultOutboxLease
+	}
+
+	published, failed := 0, 0
+
+	for ctx.Err() == nil {
+		records, err := r.repository.Claim(ctx, batchSize, lease)
+		if err != nil {
+			r.logger.Errorf("outbox relay claim failed: %s", err)
+			break
+		}
+
+		for _, record := range records {
+			if err = r.relay(ctx, record); err != nil {
+				failed++
+				continue
+			}
+			published++
+		}
+
+		if ctx.Err() != nil || len(records) < batchSize {
+			break
+		}
+	}
+
+	if published > 0 || failed > 0 {
+		r.logger.Infof("outbox relay published %d records, %d records failed", published, failed)
+	}
+
+	r.updatePendingMetric(ctx)
+}
+
+// relay publishes claimed record and marks it as published or failed. Failed record is
+// retried after backoff till max attempts count is reached.
+func (r *OutboxRelay) relay(ctx context.Context, record data.OutboxRecord) error {
+	publishErr := r.publish(ctx, record)
+
+	if publishErr == nil {
+		if err := r.repository.MarkPublished(ctx, record); err != nil {
+			r.logger.Errorf("outbox relay failed: %s", err)
+			return err
+		}
+		return nil
+	}
+
+	maxAttempts := r.config.Outbox.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultOutboxMaxAttempts
+	}
+
+	logger := r.logger.WithField("record", record.Id).WithField("attempt", record.Attempts+1)
+
+	var err error
+
+	if record.Attempts+1 >= maxAttempts {
+		logger.Errorf("outbox record publishing failed, max attempts reached: %s", publishErr)
+		err = r.repository.MarkFailed(ctx, record, publishErr)
+	} else {
+		logger.Warnf("outbox record publishing failed: %s", publishErr)
+		err = r.repository.MarkRetry(ctx, record, publishErr, time.Now().UTC().Add(r.backoff(record.Attempts)))
+	}
+
+	if err != nil {
+		r.logger.Errorf("outbox relay failed: %s", err)
+	}
+
+	return publishErr
+}
+
+// backoff returns delay before the next attempt of record failed attempts times before,
+// delay is doubled every attempt starting from min backoff and limited by max backoff.
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	minBackoff := r.config.Outbox.MinBackoff
+	if minBackoff <= 0 {
+		minBackoff = defaultOutboxMinBackoff
+	}
+
+	maxBackoff := r.config.Outbox.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultOutboxMaxBackoff
+	}
+
+	delay := minBackoff
+
+	for i := 0; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+
+	return delay
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, record data.OutboxRecord) error {
+	for _, p := range r.publishers {
+		err := p.Publish(ctx, record)
+
+		if err != nil {
+			metrics.OutboxDeliveries.WithLabelValues(p.Name(), outboxResultError).Inc()
+			return fmt.Errorf("%s publisher: %w", p.Name(), err)
+		}
+
+		metrics.OutboxDeliveries.WithLabelValues(p.Name(), outboxResultOk).Inc()
+	}
+
+	return nil
+}
+
+func (r *OutboxRelay) updatePendingMetric(ctx context.Context) {
+	pending, err := r.repository.GetPendingCount(ctx)
+
+	if err != nil {
+		r.logger.Errorf("outbox pending metric update failed: %s", err)
+		return
+	}
+
+	metrics.OutboxPending.Set(float64(pending))
+}
@@ -1,7 +1,13 @@
 package loading
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/denis-gudim/economic-calendar/loader"
+	"github.com/denis-gudim/economic-calendar/loader/data"
+	"github.com/denis-gudim/economic-calendar/loader/metrics"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -25,33 +31,145 @@ func NewRefreshCalendarService(cnf *loader.Config,
 	}
 }
 
-func (s *RefreshCalendarService) Refresh() {
+// Refresh reloads schedule rows due around now which aren't done yet and saves changed ones.
+// Failed days and rows are logged and don't stop refresh, error reports their count.
+func (s *RefreshCalendarService) Refresh(ctx context.Context) (stats data.WriteStats, err error) {
+
+	fmtError := func(msg string, err error) error {
+		return fmt.Errorf("events schedule refresh failed: %s: %w", msg, err)
+	}
+
+	result := jobResultOk
+	nowTime := time.Now().UTC()
+
+	defer func(start time.Time) {
+		metrics.ObserveJob(refreshJob, result, start)
+	}(nowTime)
+
+	from := nowTime.Add(-s.config.Loading.RefreshWindow)
+	to := nowTime.Add(s.config.Loading.RefreshWindow)
+
+	scheduleItems, err := s.eventScheduleRepository.GetByDates(ctx, truncateDay(from), truncateDay(to).AddDate(0, 0, 1))
+
+	if err != nil {
+		result = jobResultError
+		return stats, fmtError("load stored schedule", err)
+	}
+
+	dueItems := make(map[time.Time]map[int]data.EventSchedule)
+
+	for _, item := range scheduleItems {
+		// captured releases aren't polled again
+		if item.IsDone || item.TimeStamp.Before(from) || item.TimeStamp.After(to) {
+			continue
+		}
+
+		day := truncateDay(item.TimeStamp)
+
+		if _, ok := dueItems[day]; !ok {
+			dueItems[day] = make(map[int]data.EventSchedule)
+		}
+
+		dueItems[day][item.Id] = item
+	}
+
+	if len(dueItems) == 0 {
+		result = jobResultSkipped
+		s.logger.WithFields(log.Fields{"from": from, "to": to}).Debug("refresh skipped. no events scheduled around now.")
+		return stats, nil
+	}
+
+	s.logger.Info("events schedule refresh started...")
+
+	failedDays, failedRows := 0, 0
+
+	for day, items := range dueItems {
 
-	// nowTime := time.Now().UTC()
+		batch, err := s.investingRepository.GetEventsSchedule(ctx, day, day)
 
-	// if nowTime.Before(s.nextEventTime) {
+		if err != nil {
+			result = jobResultFailed
+			failedDays++
+			s.logger.WithField("date", day).Error(fmtError("load investing schedule", err))
+			continue
+		}
 
-	// 	s.logger.WithFields(log.Fields{
-	// 		"nowTime":       nowTime,
-	// 		"nextEventTime": s.nextEventTime,
-	// 	}).Debug("refresh skipped. next event time didn't come.")
+		for id, stored := range items {
 
-	// 	return
-	// }
+			translations, ok := batch[id]
 
-	// fmtError := func(msg string, err error) error {
-	// 	return fmt.Errorf("events schedule refresh failed: %s: %w", msg, err)
-	// }
+			if !ok {
+				s.logger.Debugf("event schedule row not found in source: id = %d, date = %s", id, day)
+				continue
+			}
 
-	// s.logger.Info("events schedule refresh started...")
+			fresh, err := newEventSchedule(id, translations)
 
-	// scheduleItems, err := s.eventScheduleRepository.GetByDates(nowTime, nowTime)
+			if err != nil {
+				result = jobResultFailed
+				failedRows++
+				s.logger.Error(fmtError("map investing schedule row", err))
+				continue
+			}
 
-	// if err != nil {
-	// 	err = fmtError("load countries", err)
-	// 	s.logger.WithFields(log.Fields{ "nowTime": nowTime}).Error(err)
-	// 	return
-	// }
+			if !isEventScheduleChanged(stored, fresh) {
+				continue
+			}
+
+			rowStats, err := s.eventScheduleRepository.Save(ctx, fresh)
+
+			if err != nil {
+				result = jobResultFailed
+				failedRows++
+				s.logger.Error(fmtError("save refreshed schedule row", err))
+				continue
+			}
+
+			stats.Add(rowStats)
+
+			s.logger.Infof("event schedule row refreshed: id = %d, eventId = %d", fresh.Id, fresh.EventId)
+		}
+	}
+
+	if stats.Inserted+stats.Updated > 0 {
+		updateNewestScheduleMetric(ctx, s.eventScheduleRepository, s.logger)
+	}
+
+	s.logger.Infof("events schedule refresh finished: %s", stats)
+
+	if failedDays+failedRows > 0 {
+		return stats, fmt.Errorf("events schedule refresh failed: %d of %d days and %d rows failed", failedDays, len(dueItems), failedRows)
+	}
+
+	return stats, nil
+}
+
+func isEventScheduleChanged(stored, fresh data.EventSchedule) bool {
+	return !stored.TimeStamp.Equal(fresh.TimeStamp) ||
+		!equalValues(stored.Actual, fresh.Actual) ||
+		!equalValues(stored.Forecast, fresh.Forecast) ||
+		!equalValues(stored.Previous, fresh.Previous) ||
+		!equalRawValues(stored.ActualValue, fresh.ActualValue) ||
+		!equalRawValues(stored.ForecastValue, fresh.ForecastValue) ||
+		!equalRawValues(stored.PreviousValue, fresh.PreviousValue) ||
+		stored.IsDone != fresh.IsDone ||
+		stored.Type != fresh.Type
+}
+
+func equalValues(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalRawValues(a, b *data.IndexValue) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Raw == b.Raw
+}
 
-	// s.investingRepository
+func truncateDay(t time.Time) time.Time {
+	return time.Unix(t.Unix()/daySec*daySec, 0).UTC()
 }
@@ -0,0 +1,158 @@
+package loading
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/denis-gudim/economic-calendar/loader"
+	"github.com/denis-gudim/economic-calendar/loader/data"
+	log "github.com/sirupsen/logrus"
+)
+
+const releasesHorizon = 24 * time.Hour
+
+// RefreshScheduler runs calendar refresh often around upcoming releases and rarely between them.
+type RefreshScheduler struct {
+	eventScheduleRepository EventScheduleDataReciver
+	refreshService          *RefreshCalendarService
+	sourceState             InvestingSourceState
+	logger                  *log.Logger
+	config                  *loader.Config
+	releases                []data.EventRelease
+	releasesLoadTime        time.Time
+	nextRefreshTime         time.Time
+}
+
+func NewRefreshScheduler(cnf *loader.Config,
+	logger *log.Logger,
+	eventScheduleRepository EventScheduleDataReciver,
+	refreshService *RefreshCalendarService,
+	sourceState InvestingSourceState) *RefreshScheduler {
+
+	return &RefreshScheduler{
+		eventScheduleRepository: eventScheduleRepository,
+		refreshService:          refreshService,
+		sourceState:             sourceState,
+		logger:                  logger,
+		config:                  cnf,
+	}
+}
+
+func (s *RefreshScheduler) Tick(ctx context.Context) {
+
+	nowTime := time.Now().UTC()
+
+	if nowTime.Before(s.nextRefreshTime) {
+		return
+	}
+
+	if err := s.loadReleases(ctx, nowTime); err != nil {
+		s.logger.Error(fmt.Errorf("refresh scheduling failed: %w", err))
+	}
+
+	if s.isReleaseWindow(nowTime) {
+		if !s.sourceState.IsAvailable() {
+			s.logger.Warn("calendar refresh skipped: investing source is unavailable")
+		} else {
+			stats, err := s.refreshService.Refresh(ctx)
+			if err != nil {
+				s.logger.Error(err)
+			}
+
+			// captured releases are done now, so they shouldn't keep refresh interval short
+			if stats.Inserted+stats.Updated > 0 {
+				s.releases = nil
+			}
+		}
+	}
+
+	nowTime = time.Now().UTC()
+
+	if err := s.loadReleases(ctx, nowTime); err != nil {
+		s.logger.Error(fmt.Errorf("refresh scheduling failed: %w", err))
+	}
+	s.nextRefreshTime = nowTime.Add(s.nextInterval(nowTime))
+
+	s.logger.WithFields(log.Fields{
+		"nowTime":         nowTime,
+		"nextRefreshTime": s.nextRefreshTime,
+	}).Debug("next calendar refresh scheduled")
+}
+
+func (s *RefreshScheduler) loadReleases(ctx context.Context, nowTime time.Time) error {
+
+	if s.releases != nil && nowTime.Sub(s.releasesLoadTime) < s.config.Scheduler.RefreshIdle {
+		return nil
+	}
+
+	window := s.config.Loading.RefreshWindow
+
+	releases, err := s.eventScheduleRepository.GetReleases(ctx, nowTime.Add(-window), nowTime.Add(releasesHorizon))
+
+	if err != nil {
+		return fmt.Errorf("load upcoming releases: %w", err)
+	}
+
+	s.releases = releases
+	s.releasesLoadTime = nowTime
+
+	return nil
+}
+
+func (s *RefreshScheduler) isReleaseWindow(nowTime time.Time) bool {
+	window := s.config.Loading.RefreshWindow
+
+	for _, r := range s.releases {
+		if !nowTime.Before(r.TimeStamp.Add(-window)) && !nowTime.After(r.TimeStamp.Add(window)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *RefreshScheduler) nextInterval(nowTime time.Time) time.Duration {
+	window := s.config.Loading.RefreshWindow
+	interval := s.config.Scheduler.RefreshIdle
+
+	for _, r := range s.releases {
+		windowStart := r.TimeStamp.Add(-window)
+		windowEnd := r.TimeStamp.Add(window)
+
+		if nowTime.After(windowEnd) {
+			continue
+		}
+
+		if nowTime.Before(windowStart) {
+			if d := windowStart.Sub(nowTime); d < interval {
+				interval = d
+			}
+			continue
+		}
+
+		if d := s.impactInterval(r.ImpactLevel); d < interval {
+			interval = d
+		}
+	}
+
+	return interval
+}
+
+func (s *RefreshScheduler) impactInterval(impactLevel int) time.Duration {
+	intervals := s.config.Scheduler.RefreshIntervals
+
+	if len(intervals) == 0 {
+		return s.config.Scheduler.RefreshIdle
+	}
+
+	i := impactLevel - 1
+
+	if i < 0 {
+		i = 0
+	} else if i >= len(intervals) {
+		i = len(intervals) - 1
+	}
+
+	return intervals[i]
+}
@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "loader"
+
+var (
+	SourceCircuitState = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "source",
+		Name:      "circuit_state",
+		Help:      "Investing source circuit breaker state: 0 - closed, 1 - half open, 2 - open.",
+	})
+	SourceCircuitTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "source",
+		Name:      "circuit_transitions_total",
+		Help:      "Number of investing source circuit breaker transitions by target state.",
+	}, []string{"state"})
+	SourceSkippedRows = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "source",
+		Name:      "skipped_rows_total",
+		Help:      "Number of source document rows skipped by lenient parsing by document kind.",
+	}, []string{"document"})
+	SourceRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "source",
+		Name:      "requests_total",
+		Help:      "Number of investing source HTTP requests by endpoint, language and response status.",
+	}, []string{"endpoint", "language", "status"})
+	SourceRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "source",
+		Name:      "request_duration_seconds",
+		Help:      "Investing source HTTP request latency by endpoint and language.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"endpoint", "language"})
+	SourceParseErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "source",
+		Name:      "parse_errors_total",
+		Help:      "Number of source documents failed to parse completely or partially by document kind.",
+	}, []string{"document"})
+	RowsUpserted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "rows_upserted_total",
+		Help:      "Number of rows upserted into database by repository and action.",
+	}, []string{"repository", "action"})
+	RowsUnchanged = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "rows_unchanged_total",
+		Help:      "Number of rows skipped by repository as unchanged since stored content hash matches.",
+	}, []string{"repository"})
+	JobRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "job",
+		Name:      "runs_total",
+		Help:      "Number of loader job runs by job and outcome.",
+	}, []string{"job", "result"})
+	JobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "job",
+		Name:      "duration_seconds",
+		Help:      "Loader job run duration by job.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 4, 10),
+	}, []string{"job"})
+	ScheduleNewestTimestamp = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "schedule_newest_done_timestamp_seconds",
+		Help:      "Unix time of the newest stored events schedule row which is done.",
+	})
+	CanaryDrift = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "canary",
+		Name:      "drift",
+		Help:      "Parser canary state: 0 - canary documents match baseline, 1 - drift or check error.",
+	})
+	CanaryStat = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "canary",
+		Name:      "stat",
+		Help:      "Field coverage rates of parser canary documents by metric name.",
+	}, []string{"metric"})
+	CanaryRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "canary",
+		Name:      "runs_total",
+		Help:      "Number of parser canary checks by result.",
+	}, []string{"result"})
+	OutboxDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "outbox",
+		Name:      "deliveries_total",
+		Help:      "Number of outbox records delivery attempts by publisher and result.",
+	}, []string{"publisher", "result"})
+	OutboxPending = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "outbox",
+		Name:      "pending_records",
+		Help:      "Number of outbox records waiting for delivery.",
+	})
+)
+
+// ObserveJob records outcome and duration of job run started at start time.
+func ObserveJob(job, result string, start time.Time) {
+	JobRuns.WithLabelValues(job, result).Inc()
+	JobDuration.WithLabelValues(job).Observe(time.Since(start).Seconds())
+}